@@ -14,6 +14,12 @@ func TestChainIDString(t *testing.T) {
 		{"Rinkeby", Rinkeby},
 		{"Goerli", Goerli},
 		{"Kovan", Kovan},
+		{"Optimism", Optimism},
+		{"BSC", BSC},
+		{"Polygon", Polygon},
+		{"Base", Base},
+		{"Arbitrum", Arbitrum},
+		{"Sepolia", Sepolia},
 		{"ChainID(2)", ChainID(2)},
 	}
 	for i, test := range tests {
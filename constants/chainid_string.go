@@ -12,13 +12,25 @@ func _() {
 	_ = x[Ropsten-3]
 	_ = x[Rinkeby-4]
 	_ = x[Goerli-5]
+	_ = x[Optimism-10]
 	_ = x[Kovan-42]
+	_ = x[BSC-56]
+	_ = x[Polygon-137]
+	_ = x[Base-8453]
+	_ = x[Arbitrum-42161]
+	_ = x[Sepolia-11155111]
 }
 
 const (
 	_ChainID_name_0 = "Mainnet"
 	_ChainID_name_1 = "RopstenRinkebyGoerli"
-	_ChainID_name_2 = "Kovan"
+	_ChainID_name_2 = "Optimism"
+	_ChainID_name_3 = "Kovan"
+	_ChainID_name_4 = "BSC"
+	_ChainID_name_5 = "Polygon"
+	_ChainID_name_6 = "Base"
+	_ChainID_name_7 = "Arbitrum"
+	_ChainID_name_8 = "Sepolia"
 )
 
 var (
@@ -32,8 +44,20 @@ func (i ChainID) String() string {
 	case 3 <= i && i <= 5:
 		i -= 3
 		return _ChainID_name_1[_ChainID_index_1[i]:_ChainID_index_1[i+1]]
-	case i == 42:
+	case i == 10:
 		return _ChainID_name_2
+	case i == 42:
+		return _ChainID_name_3
+	case i == 56:
+		return _ChainID_name_4
+	case i == 137:
+		return _ChainID_name_5
+	case i == 8453:
+		return _ChainID_name_6
+	case i == 42161:
+		return _ChainID_name_7
+	case i == 11155111:
+		return _ChainID_name_8
 	default:
 		return "ChainID(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
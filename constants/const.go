@@ -14,19 +14,33 @@ const (
 	ExactOutput
 )
 
+// Rounding the rounding modes, which match the rounding modes of big.js and decimal.js
 type Rounding int
 
 const (
+	// RoundDown rounds towards zero
 	RoundDown Rounding = iota
+	// RoundHalfUp rounds towards the nearest neighbour, or away from zero if equidistant
 	RoundHalfUp
+	// RoundUp rounds away from zero
 	RoundUp
+	// RoundHalfEven rounds towards the nearest neighbour, or towards the even neighbour if equidistant, i.e. banker's
+	// rounding
+	RoundHalfEven
+	// RoundHalfDown rounds towards the nearest neighbour, or towards zero if equidistant
+	RoundHalfDown
+	// RoundCeiling rounds towards positive infinity
+	RoundCeiling
+	// RoundFloor rounds towards negative infinity
+	RoundFloor
 )
 
+// RoundTruncate truncates the digits beyond the precision, it is RoundDown
+const RoundTruncate = RoundDown
+
 // Valid check this rounding mode is valid
 func (r Rounding) Valid() bool {
-	return r == RoundDown ||
-		r == RoundHalfUp ||
-		r == RoundUp
+	return r >= RoundDown && r <= RoundFloor
 }
 
 const (
@@ -6,10 +6,10 @@ import (
 	"testing"
 )
 
-// NOTE: Make sure that the RoundUp here is the largest constant
+// NOTE: Make sure that the RoundFloor here is the largest constant
 func randWholeNumber() int {
 	max := big.NewInt(10)
-	min := int(RoundUp + 1)
+	min := int(RoundFloor + 1)
 	i, err := rand.Int(rand.Reader, max)
 	if err != nil {
 		panic(err)
@@ -37,6 +37,11 @@ func TestRounding_Valid(t *testing.T) {
 		{"should return true if Rounding is RoundDown", RoundDown, true},
 		{"should return true if Rounding is RoundHalfUp", RoundHalfUp, true},
 		{"should return true if Rounding is RoundUp", RoundUp, true},
+		{"should return true if Rounding is RoundHalfEven", RoundHalfEven, true},
+		{"should return true if Rounding is RoundHalfDown", RoundHalfDown, true},
+		{"should return true if Rounding is RoundCeiling", RoundCeiling, true},
+		{"should return true if Rounding is RoundFloor", RoundFloor, true},
+		{"should return true if Rounding is RoundTruncate", RoundTruncate, true},
 		{"should return true if Rounding is other whole numbers", Rounding(randWholeNumber()), false},
 		{"should return true if Rounding is other negative numbers", Rounding(randNegativeNumber()), false},
 	}
@@ -0,0 +1,256 @@
+package entities
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+)
+
+var (
+	ErrInvalidMaxHops = fmt.Errorf("invalid maxHops")
+
+	// the maximum number of input amounts searched exhaustively for the exact optimum of a cycle
+	maxArbitrageScan = big.NewInt(1 << 16)
+)
+
+// Arbitrage Represents a profitable cycle of pairs which starts and ends with the same token.
+type Arbitrage struct {
+	/**
+	 * The exact in trade of the optimal input amount through the cycle, the optimum of the continuous model refined
+	 * with the exact integer math of the pairs. Its profit is the exact optimum unless the reserves are so large that
+	 * more than 65536 input amounts may beat the refined one, then it may miss the optimum by the rounding of the pairs.
+	 */
+	Trade *Trade
+	/**
+	 * The product of the mid prices of the cycle including the swap fees, which is greater than 1.
+	 */
+	Rate *Fraction
+	/**
+	 * The output amount minus the input amount of the trade.
+	 */
+	Profit *TokenAmount
+}
+
+/**
+ * Given a list of pairs and a base token, returns the cycles from the base token back to itself, making at most
+ * `maxHops` hops, whose product of mid prices including the swap fees exceeds 1, sorted by profit in decreasing order.
+ * @param pairs the pairs to consider in finding the cycles
+ * @param base the token the cycles start and end with
+ * @param maxHops maximum number of hops a cycle can make, at least 2
+ */
+func FindArbitrages(pairs []*Pair, base *Token, maxHops int) ([]*Arbitrage, error) {
+	return NewPairGraph(pairs).FindArbitrages(base, maxHops)
+}
+
+// FindArbitrages returns the profitable cycles of the graph, see FindArbitrages
+func (g *PairGraph) FindArbitrages(base *Token, maxHops int) ([]*Arbitrage, error) {
+	if maxHops < 2 {
+		return nil, ErrInvalidMaxHops
+	}
+
+	var arbitrages []*Arbitrage
+	used := make([]bool, len(g.pairs))
+	path := make([]*Pair, 0, maxHops)
+	var search func(token *Token) error
+	search = func(token *Token) error {
+		for _, i := range g.adjacency[newTokenKey(token)] {
+			if used[i] {
+				continue
+			}
+			pair := g.pairs[i]
+			if pair.Reserve0().EqualTo(ZeroFraction) || pair.Reserve1().EqualTo(ZeroFraction) {
+				continue
+			}
+			next := pair.Token0()
+			if token.Equals(next) {
+				next = pair.Token1()
+			}
+
+			used[i] = true
+			path = append(path, pair)
+			var err error
+			if next.Equals(base) {
+				if len(path) > 1 {
+					err = g.appendArbitrage(&arbitrages, path, base)
+				}
+			} else if len(path) < maxHops {
+				err = search(next)
+			}
+			path = path[:len(path)-1]
+			used[i] = false
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := search(base); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(arbitrages, func(i, j int) bool {
+		return arbitrages[i].Profit.GreaterThan(arbitrages[j].Profit.Fraction)
+	})
+	return arbitrages, nil
+}
+
+func (g *PairGraph) appendArbitrage(arbitrages *[]*Arbitrage, path []*Pair, base *Token) error {
+	// the output of the cycle for x is A*x / (B + C*x), composed hop by hop with
+	// out = feeNumerator*reserveOut*x / (feeDenominator*reserveIn + feeNumerator*x)
+	a, b, c := big.NewInt(1), big.NewInt(1), big.NewInt(0)
+	token := base
+	for _, pair := range path {
+		reserveIn, reserveOut := pair.Reserve0(), pair.Reserve1()
+		if token.Equals(pair.Token1()) {
+			reserveIn, reserveOut = reserveOut, reserveIn
+		}
+		n, d := pair.Protocol.FeeNumerator, pair.Protocol.FeeDenominator
+
+		dr := big.NewInt(0).Mul(d, reserveIn.Raw())
+		c.Add(c.Mul(c, dr), big.NewInt(0).Mul(n, a))
+		b.Mul(b, dr)
+		a.Mul(a, n).Mul(a, reserveOut.Raw())
+		token = reserveOut.Token
+	}
+	// the rate at an infinitesimal input is A/B
+	if a.Cmp(b) <= 0 {
+		return nil
+	}
+
+	// the profit A*x / (B + C*x) - x is maximized at x = (sqrt(A*B) - B) / C
+	optimal := big.NewInt(0).Mul(a, b)
+	optimal.Sqrt(optimal).Sub(optimal, b).Div(optimal, c)
+	pairs := make([]*Pair, len(path))
+	copy(pairs, path)
+	route, err := NewRoute(pairs, base, base)
+	if err != nil {
+		return err
+	}
+	trade, profit, err := bestArbitrageTrade(route, a, b, c, optimal)
+	if err != nil {
+		return err
+	}
+	if trade == nil {
+		return nil
+	}
+
+	*arbitrages = append(*arbitrages, &Arbitrage{
+		Trade:  trade,
+		Rate:   NewFraction(a, b),
+		Profit: profit,
+	})
+	return nil
+}
+
+// bestArbitrageTrade refines the optimal input amount of the continuous model with the exact integer math of the
+// pairs, it returns a nil trade if there is no profit.
+// The exact output of the cycle never exceeds the continuous output A*x / (B + C*x), so once a profit p is found only
+// the inputs whose continuous profit reaches p can do better, they are searched exhaustively if there are at most
+// maxArbitrageScan of them, which makes the optimum exact for all but the largest reserves.
+func bestArbitrageTrade(route *Route, a, b, c, optimal *big.Int) (*Trade, *TokenAmount, error) {
+	var bestInput, bestProfit *big.Int
+	evaluate := func(x *big.Int) (*big.Int, error) {
+		if x.Sign() <= 0 {
+			return nil, nil
+		}
+		amount, err := NewTokenAmount(route.Input, x)
+		if err != nil {
+			return nil, err
+		}
+		for i, pair := range route.Pairs {
+			if amount, _, err = pair.getOutputAmountFromPool(amount, i > 0); err != nil {
+				break
+			}
+		}
+		if err == ErrInsufficientInputAmount || err == ErrInsufficientReserves {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		profit := big.NewInt(0).Sub(amount.Raw(), x)
+		if profit.Sign() > 0 && (bestProfit == nil || profit.Cmp(bestProfit) > 0 ||
+			profit.Cmp(bestProfit) == 0 && x.Cmp(bestInput) < 0) {
+			bestInput, bestProfit = big.NewInt(0).Set(x), profit
+		}
+		return profit, nil
+	}
+
+	// the exact profit is nearly concave, ternary search it around the optimal input of the continuous model
+	lo, hi := big.NewInt(1), big.NewInt(0).Mul(optimal, constants.Two)
+	if _, err := evaluate(optimal); err != nil {
+		return nil, nil, err
+	}
+	for big.NewInt(0).Sub(hi, lo).Cmp(constants.Two) > 0 {
+		third := big.NewInt(0).Sub(hi, lo)
+		third.Div(third, constants.Three)
+		m1 := big.NewInt(0).Add(lo, third)
+		m2 := big.NewInt(0).Sub(hi, third)
+		p1, err := evaluate(m1)
+		if err != nil {
+			return nil, nil, err
+		}
+		p2, err := evaluate(m2)
+		if err != nil {
+			return nil, nil, err
+		}
+		if p1 == nil || (p2 != nil && p1.Cmp(p2) < 0) {
+			lo = m1
+		} else {
+			hi = m2
+		}
+	}
+	for x := big.NewInt(0).Set(lo); x.Cmp(hi) <= 0; x = big.NewInt(0).Add(x, constants.One) {
+		if _, err := evaluate(x); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// the inputs whose continuous profit is at least p solve C*x^2 + (B + C*p - A)*x + B*p <= 0
+	p := big.NewInt(1)
+	if bestProfit != nil {
+		p.Set(bestProfit)
+	}
+	negB := big.NewInt(0).Mul(c, p)
+	negB.Add(negB, b).Sub(a, negB)
+	discriminant := big.NewInt(0).Mul(negB, negB)
+	discriminant.Sub(discriminant, big.NewInt(0).Mul(big.NewInt(4), big.NewInt(0).Mul(c, big.NewInt(0).Mul(b, p))))
+	if negB.Sign() > 0 && discriminant.Sign() >= 0 {
+		root := big.NewInt(0).Sqrt(discriminant)
+		twoC := big.NewInt(0).Mul(constants.Two, c)
+		// widen the bounds by one for the truncations of the square root and the divisions
+		lo = big.NewInt(0).Sub(negB, root)
+		lo.Div(lo, twoC).Sub(lo, constants.One)
+		if lo.Sign() <= 0 {
+			lo.SetInt64(1)
+		}
+		hi = big.NewInt(0).Add(negB, root)
+		hi.Add(hi, constants.One).Div(hi, twoC).Add(hi, constants.One)
+		if big.NewInt(0).Sub(hi, lo).Cmp(maxArbitrageScan) <= 0 {
+			for x := big.NewInt(0).Set(lo); x.Cmp(hi) <= 0; x = big.NewInt(0).Add(x, constants.One) {
+				if _, err := evaluate(x); err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+	}
+
+	if bestProfit == nil {
+		return nil, nil, nil
+	}
+	amountIn, err := NewTokenAmount(route.Input, bestInput)
+	if err != nil {
+		return nil, nil, err
+	}
+	trade, err := ExactIn(route, amountIn)
+	if err != nil {
+		return nil, nil, err
+	}
+	profit, err := NewTokenAmount(route.Input, bestProfit)
+	if err != nil {
+		return nil, nil, err
+	}
+	return trade, profit, nil
+}
@@ -0,0 +1,112 @@
+package entities
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+)
+
+// nolint funlen
+func TestFindArbitrages(t *testing.T) {
+	weth := WETH[constants.Mainnet]
+	tokenA, _ := NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000001"), 18, "A", "")
+	tokenB, _ := NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000002"), 18, "B", "")
+
+	newPair := func(tokenA *Token, reserveA int64, tokenB *Token, reserveB int64, protocol *Protocol) *Pair {
+		tokenAmountA, _ := NewTokenAmount(tokenA, big.NewInt(reserveA))
+		tokenAmountB, _ := NewTokenAmount(tokenB, big.NewInt(reserveB))
+		pair, err := NewPairWithProtocol(tokenAmountA, tokenAmountB, protocol)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pair
+	}
+	pair_weth_a := newPair(weth, 1000, tokenA, 1000, UniswapV2)
+	pair_a_b := newPair(tokenA, 1000, tokenB, 1000, UniswapV2)
+	// B is cheap in terms of WETH here
+	pair_b_weth := newPair(tokenB, 1000, weth, 1500, UniswapV2)
+	// A is expensive in terms of WETH on another protocol
+	pancake_weth_a := newPair(weth, 1200, tokenA, 1000, PancakeSwapV2)
+
+	// brute force the exact best profit of a cycle
+	bruteForce := func(route *Route, max int64) *big.Int {
+		best := big.NewInt(0)
+		for x := int64(1); x <= max; x++ {
+			amountIn, _ := NewTokenAmount(route.Input, big.NewInt(x))
+			trade, err := ExactIn(route, amountIn)
+			if err != nil {
+				continue
+			}
+			profit := big.NewInt(0).Sub(trade.OutputAmount().Raw(), big.NewInt(x))
+			if profit.Cmp(best) > 0 {
+				best = profit
+			}
+		}
+		return best
+	}
+
+	// throws with max hops less than 2
+	{
+		_, output := FindArbitrages([]*Pair{pair_weth_a}, weth, 1)
+		if output != ErrInvalidMaxHops {
+			t.Errorf("expect[%+v], but got[%+v]", ErrInvalidMaxHops, output)
+		}
+	}
+
+	// no cycle without mispricing
+	{
+		result, err := FindArbitrages([]*Pair{pair_weth_a, pair_a_b, newPair(tokenB, 1000, weth, 1000, UniswapV2)}, weth, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(result) != 0 {
+			t.Errorf("expect[0], but got[%+v]", len(result))
+		}
+	}
+
+	result, err := FindArbitrages([]*Pair{pair_weth_a, pair_a_b, pair_b_weth, pancake_weth_a}, weth, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// WETH -> A -> B -> WETH, WETH -> A -> WETH (pancake) and WETH -> A (pancake) -> B -> WETH
+	if len(result) != 3 {
+		t.Fatalf("expect[3], but got[%+v]", len(result))
+	}
+	for i, arbitrage := range result {
+		if !arbitrage.Rate.GreaterThan(NewFraction(constants.One, nil)) {
+			t.Errorf("test #%d: rate[%+v] should be greater than 1", i, arbitrage.Rate.ToSignificant(6))
+		}
+		if i > 0 && arbitrage.Profit.GreaterThan(result[i-1].Profit.Fraction) {
+			t.Errorf("test #%d: should be sorted by profit", i)
+		}
+		route := arbitrage.Trade.Route
+		if !route.Input.Equals(weth) || !route.Output.Equals(weth) {
+			t.Errorf("test #%d: cycle should start and end with WETH", i)
+		}
+
+		// the profit is the exact optimum
+		expect := bruteForce(route, 1000)
+		if arbitrage.Profit.Raw().Cmp(expect) != 0 {
+			t.Errorf("test #%d: expect profit[%+v], but got[%+v]", i, expect, arbitrage.Profit.Raw())
+		}
+		output := big.NewInt(0).Sub(arbitrage.Trade.OutputAmount().Raw(), arbitrage.Trade.InputAmount().Raw())
+		if arbitrage.Profit.Raw().Cmp(output) != 0 {
+			t.Errorf("test #%d: expect profit[%+v], but got[%+v]", i, output, arbitrage.Profit.Raw())
+		}
+	}
+	{
+		expect := []*Pair{pair_weth_a, pair_a_b, pair_b_weth}
+		output := result[0].Trade.Route.Pairs
+		if len(output) != len(expect) {
+			t.Fatalf("expect[%+v], but got[%+v]", len(expect), len(output))
+		}
+		for i := range expect {
+			if expect[i] != output[i] {
+				t.Errorf("test #%d: expect[%+v], but got[%+v]", i, expect[i], output[i])
+			}
+		}
+	}
+}
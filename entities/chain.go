@@ -0,0 +1,155 @@
+package entities
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+)
+
+var (
+	// ErrUnknownChain the chain is not registered
+	ErrUnknownChain = fmt.Errorf("unknown chain")
+	// ErrInvalidChain the chain has no native currency, or its wrapped native token is on another chain or has
+	// other decimals
+	ErrInvalidChain = fmt.Errorf("invalid chain")
+	// ErrNoProtocol the chain has no DEX deployment
+	ErrNoProtocol = fmt.Errorf("no protocol on the chain")
+
+	chains = newChainRegistry(
+		&Chain{ChainID: constants.Mainnet, Name: "Ethereum", NativeCurrency: ETHER,
+			WrappedNative: WETH[constants.Mainnet], BlockTime: 12 * time.Second, Protocols: []*Protocol{UniswapV2}},
+		&Chain{ChainID: constants.Ropsten, Name: "Ropsten", NativeCurrency: ETHER,
+			WrappedNative: WETH[constants.Ropsten], BlockTime: 12 * time.Second, Protocols: []*Protocol{UniswapV2}},
+		&Chain{ChainID: constants.Rinkeby, Name: "Rinkeby", NativeCurrency: ETHER,
+			WrappedNative: WETH[constants.Rinkeby], BlockTime: 15 * time.Second, Protocols: []*Protocol{UniswapV2}},
+		&Chain{ChainID: constants.Goerli, Name: "Goerli", NativeCurrency: ETHER,
+			WrappedNative: WETH[constants.Goerli], BlockTime: 12 * time.Second, Protocols: []*Protocol{UniswapV2}},
+		&Chain{ChainID: constants.Kovan, Name: "Kovan", NativeCurrency: ETHER,
+			WrappedNative: WETH[constants.Kovan], BlockTime: 4 * time.Second, Protocols: []*Protocol{UniswapV2}},
+		&Chain{ChainID: constants.Sepolia, Name: "Sepolia", NativeCurrency: ETHER,
+			WrappedNative: WETH[constants.Sepolia], BlockTime: 12 * time.Second, Protocols: []*Protocol{UniswapV2Sepolia}},
+		&Chain{ChainID: constants.Optimism, Name: "Optimism", NativeCurrency: ETHER,
+			WrappedNative: WETH[constants.Optimism], BlockTime: 2 * time.Second, Protocols: []*Protocol{UniswapV2Optimism}},
+		&Chain{ChainID: constants.Arbitrum, Name: "Arbitrum One", NativeCurrency: ETHER,
+			WrappedNative: WETH[constants.Arbitrum], BlockTime: 250 * time.Millisecond, Protocols: []*Protocol{UniswapV2Arbitrum}},
+		&Chain{ChainID: constants.Base, Name: "Base", NativeCurrency: ETHER,
+			WrappedNative: WETH[constants.Base], BlockTime: 2 * time.Second, Protocols: []*Protocol{UniswapV2Base}},
+		&Chain{ChainID: constants.BSC, Name: "BNB Smart Chain", NativeCurrency: BNB,
+			WrappedNative: WBNB, BlockTime: 3 * time.Second, Protocols: []*Protocol{PancakeSwapV2, PancakeSwapV1, UniswapV2BSC}},
+		&Chain{ChainID: constants.Polygon, Name: "Polygon", NativeCurrency: MATIC,
+			WrappedNative: WMATIC, BlockTime: 2 * time.Second, Protocols: []*Protocol{QuickSwap, UniswapV2Polygon}},
+	)
+)
+
+// Chain describes a chain the pairs are deployed on
+type Chain struct {
+	ChainID constants.ChainID
+	Name    string
+	// the currency gas is paid in, e.g. ETHER, which is wrapped to WrappedNative when it goes through pairs
+	NativeCurrency *Currency
+	// the wrapped native token, e.g. WETH, nil if there is none
+	WrappedNative *Token
+	// the average time between blocks
+	BlockTime time.Duration
+	// the DEX deployments on the chain, the first one is the default
+	Protocols []*Protocol
+}
+
+// DefaultProtocol returns the default DEX deployment of the chain
+func (c *Chain) DefaultProtocol() (*Protocol, error) {
+	if len(c.Protocols) == 0 {
+		return nil, ErrNoProtocol
+	}
+	return c.Protocols[0], nil
+}
+
+func (c *Chain) validate() error {
+	if c.ChainID <= 0 || c.NativeCurrency == nil || c.BlockTime < 0 {
+		return ErrInvalidChain
+	}
+	if c.WrappedNative != nil && (c.WrappedNative.ChainID != c.ChainID ||
+		c.WrappedNative.Currency == c.NativeCurrency ||
+		c.WrappedNative.Decimals != c.NativeCurrency.Decimals) {
+		return ErrInvalidChain
+	}
+	for _, protocol := range c.Protocols {
+		if protocol == nil {
+			return ErrInvalidChain
+		}
+	}
+	return nil
+}
+
+type chainRegistry struct {
+	lk     *sync.RWMutex
+	chains map[constants.ChainID]*Chain
+	// the native currencies of the chains
+	natives map[*Currency]bool
+}
+
+func newChainRegistry(builtin ...*Chain) *chainRegistry {
+	r := &chainRegistry{
+		lk:     new(sync.RWMutex),
+		chains: make(map[constants.ChainID]*Chain, len(builtin)),
+	}
+	for _, chain := range builtin {
+		r.chains[chain.ChainID] = chain
+	}
+	r.indexNatives()
+	return r
+}
+
+func (r *chainRegistry) indexNatives() {
+	r.natives = make(map[*Currency]bool, len(r.chains))
+	for _, chain := range r.chains {
+		r.natives[chain.NativeCurrency] = true
+	}
+}
+
+// RegisterChain registers the chain, replacing the chain with the same id if any, the chain must not be modified
+// afterwards. The native currency of the chain is wrapped by Currency.Wrapped and Token.Wrapped, and a native
+// currency shared by several chains, e.g. ETHER, must be the same *Currency.
+func RegisterChain(chain *Chain) error {
+	if err := chain.validate(); err != nil {
+		return err
+	}
+
+	chains.lk.Lock()
+	defer chains.lk.Unlock()
+	if chain.WrappedNative != nil && chains.natives[chain.WrappedNative.Currency] {
+		return ErrInvalidChain
+	}
+	chains.chains[chain.ChainID] = chain
+	chains.indexNatives()
+	return nil
+}
+
+// GetChain returns the registered chain
+func GetChain(chainID constants.ChainID) (*Chain, error) {
+	chains.lk.RLock()
+	defer chains.lk.RUnlock()
+
+	chain, ok := chains.chains[chainID]
+	if !ok {
+		return nil, ErrUnknownChain
+	}
+	return chain, nil
+}
+
+// Chains returns the registered chains ordered by chain id
+func Chains() []*Chain {
+	chains.lk.RLock()
+	result := make([]*Chain, 0, len(chains.chains))
+	for _, chain := range chains.chains {
+		result = append(result, chain)
+	}
+	chains.lk.RUnlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ChainID < result[j].ChainID
+	})
+	return result
+}
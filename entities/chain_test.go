@@ -0,0 +1,159 @@
+package entities
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+)
+
+func TestBuiltinChains(t *testing.T) {
+	tests := []struct {
+		ChainID  constants.ChainID
+		Native   *Currency
+		Wrapped  string
+		Protocol *Protocol
+	}{
+		{constants.Mainnet, ETHER, "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", UniswapV2},
+		{constants.Sepolia, ETHER, "0xfFf9976782d46CC05630D1f6eBAb18b2324d6B14", UniswapV2Sepolia},
+		{constants.Optimism, ETHER, "0x4200000000000000000000000000000000000006", UniswapV2Optimism},
+		{constants.Arbitrum, ETHER, "0x82aF49447D8a07e3bd95BD0d56f35241523fBab1", UniswapV2Arbitrum},
+		{constants.Base, ETHER, "0x4200000000000000000000000000000000000006", UniswapV2Base},
+		{constants.BSC, BNB, "0xbb4CdB9CBd36B01bD1cBaEBF2De08d9173bc095c", PancakeSwapV2},
+		{constants.Polygon, MATIC, "0x0d500B1d8E8eF31E21C99d1Db9A6444d3ADf1270", QuickSwap},
+	}
+	for _, test := range tests {
+		chain, err := GetChain(test.ChainID)
+		if err != nil {
+			t.Fatalf("%v: %v", test.ChainID, err)
+		}
+		if chain.NativeCurrency != test.Native {
+			t.Errorf("%v: expect[%+v], but got[%+v]", test.ChainID, test.Native, chain.NativeCurrency)
+		}
+		wrapped, err := test.Native.Wrapped(test.ChainID)
+		if err != nil {
+			t.Fatalf("%v: %v", test.ChainID, err)
+		}
+		if wrapped.Address != common.HexToAddress(test.Wrapped) || wrapped.ChainID != test.ChainID {
+			t.Errorf("%v: expect[%+v], but got[%+v]", test.ChainID, test.Wrapped, wrapped.Address.Hex())
+		}
+		if protocol, _ := chain.DefaultProtocol(); protocol != test.Protocol {
+			t.Errorf("%v: expect[%+v], but got[%+v]", test.ChainID, test.Protocol.FactoryAddress.Hex(), protocol.FactoryAddress.Hex())
+		}
+	}
+
+	// the native currency of another chain is not wrapped
+	if _, err := ETHER.Wrapped(constants.BSC); err != ErrInvalidCurrency {
+		t.Errorf("expect[%+v], but got[%+v]", ErrInvalidCurrency, err)
+	}
+	if _, err := WBNB.Currency.Wrapped(constants.BSC); err != ErrInvalidCurrency {
+		t.Errorf("expect[%+v], but got[%+v]", ErrInvalidCurrency, err)
+	}
+	if _, err := GetChain(constants.ChainID(2)); err != ErrUnknownChain {
+		t.Errorf("expect[%+v], but got[%+v]", ErrUnknownChain, err)
+	}
+
+	chains := Chains()
+	for i := 1; i < len(chains); i++ {
+		if chains[i-1].ChainID >= chains[i].ChainID {
+			t.Fatalf("chains are not sorted: %v, %v", chains[i-1].ChainID, chains[i].ChainID)
+		}
+	}
+}
+
+// nolint funlen
+func TestRegisterChain(t *testing.T) {
+	const gnosis = constants.ChainID(100)
+	xDAI, _ := newCurrency(constants.Decimals18, "xDAI", "xDai")
+	wxDAI, _ := NewToken(gnosis, common.HexToAddress("0xe91D153E0b41518A2Ce8Dd3D7944Fa863463a97d"), 18, "WXDAI", "Wrapped XDAI")
+	token, _ := NewToken(gnosis, common.HexToAddress("0x0000000000000000000000000000000000000001"), 18, "", "")
+
+	invalids := []*Chain{
+		{ChainID: gnosis},
+		{ChainID: 0, NativeCurrency: xDAI},
+		{ChainID: gnosis, NativeCurrency: xDAI, WrappedNative: WBNB},
+		{ChainID: gnosis, NativeCurrency: xDAI, WrappedNative: NewETHRToken(gnosis, wxDAI.Address)},
+		{ChainID: gnosis, NativeCurrency: xDAI, Protocols: []*Protocol{nil}},
+	}
+	for i, chain := range invalids {
+		if err := RegisterChain(chain); err != ErrInvalidChain {
+			t.Errorf("test #%d: expect[%+v], but got[%+v]", i, ErrInvalidChain, err)
+		}
+	}
+	if xDAI.IsNative() {
+		t.Fatal("should not be native before the chain is registered")
+	}
+
+	err := RegisterChain(&Chain{
+		ChainID:        gnosis,
+		Name:           "Gnosis",
+		NativeCurrency: xDAI,
+		WrappedNative:  wxDAI,
+		BlockTime:      5 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !xDAI.IsNative() {
+		t.Error("should be native after the chain is registered")
+	}
+	chain, err := GetChain(gnosis)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chain.Name != "Gnosis" {
+		t.Errorf("expect[%+v], but got[%+v]", "Gnosis", chain.Name)
+	}
+	if _, err := chain.DefaultProtocol(); err != ErrNoProtocol {
+		t.Errorf("expect[%+v], but got[%+v]", ErrNoProtocol, err)
+	}
+
+	// the native currency is wrapped through pairs
+	amount, err := NewNativeAmount(gnosis, big.NewInt(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if amount.Token.Currency != xDAI || amount.Token.Address != wxDAI.Address {
+		t.Errorf("unexpected native amount[%+v]", amount.Token)
+	}
+	pair, err := NewPair(mustTokenAmount(t, wxDAI, 1000), mustTokenAmount(t, token, 1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	route, err := NewRoute([]*Pair{pair}, amount.Token, token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !route.Path[0].Equals(wxDAI) || route.Input.Currency != xDAI {
+		t.Errorf("unexpected route input[%+v] and path[%+v]", route.Input, route.Path)
+	}
+
+	// native currencies are decoded to the registered currency
+	data, err := json.Marshal(amount.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Token
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Currency != xDAI {
+		t.Errorf("expect[%+v], but got[%+v]", xDAI, decoded.Currency)
+	}
+
+	// replace the chain
+	err = RegisterChain(&Chain{ChainID: gnosis, Name: "Gnosis", NativeCurrency: ETHER})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if xDAI.IsNative() {
+		t.Error("should not be native after the chain is replaced")
+	}
+	if _, err := ETHER.Wrapped(gnosis); err != ErrNoWETH {
+		t.Errorf("expect[%+v], but got[%+v]", ErrNoWETH, err)
+	}
+}
@@ -15,11 +15,30 @@ var (
 	ETHER, _ = newCurrency(constants.Decimals18, "ETH", "Ether")
 )
 
+// the native currencies of the built-in chains whose native currency is not ETHER
+var (
+	BNB, _   = newCurrency(constants.Decimals18, "BNB", "BNB")
+	MATIC, _ = newCurrency(constants.Decimals18, "MATIC", "Matic")
+)
+
 var (
 	// ErrInvalidCurrency diff currency error
 	ErrInvalidCurrency = fmt.Errorf("diff currency")
+	// ErrNoWETH there is no WETH, or wrapped native token, on the chain
+	ErrNoWETH = fmt.Errorf("no WETH on the chain")
 )
 
+// Asset is either a native currency such as ETHER or an ERC20 Token, routes, trades and prices can be expressed in
+// any of them. A native currency is wrapped to the wrapped native token of the chain, e.g. WETH, when it goes through
+// pairs.
+type Asset interface {
+	// Wrapped returns the token that represents the asset in pairs on the given chain, i.e. WETH for ETHER
+	Wrapped(chainID constants.ChainID) (*Token, error)
+	// Unwrapped returns the token that amounts of the asset are expressed in on the given chain,
+	// i.e. a token whose Currency is ETHER at the WETH address for ETHER
+	Unwrapped(chainID constants.ChainID) (*Token, error)
+}
+
 // Currency is any fungible financial instrument on Ethereum, including Ether and all ERC20 tokens.
 type Currency struct {
 	Decimals int
@@ -45,6 +64,46 @@ func newCurrency(decimals int, symbol, name string) (*Currency, error) {
 	}, nil
 }
 
+// IsNative returns true if the currency is the native currency of a registered chain, e.g. ETHER
+func (c *Currency) IsNative() bool {
+	chains.lk.RLock()
+	defer chains.lk.RUnlock()
+	return chains.natives[c]
+}
+
+// Wrapped returns the wrapped native token of the chain, e.g. WETH, if the currency is the native currency of the chain
+func (c *Currency) Wrapped(chainID constants.ChainID) (*Token, error) {
+	if !c.IsNative() {
+		return nil, ErrInvalidCurrency
+	}
+
+	chain, err := GetChain(chainID)
+	if err != nil {
+		return nil, ErrNoWETH
+	}
+	if chain.NativeCurrency != c {
+		return nil, ErrInvalidCurrency
+	}
+	if chain.WrappedNative == nil {
+		return nil, ErrNoWETH
+	}
+	return chain.WrappedNative, nil
+}
+
+// Unwrapped returns a token whose Currency is the native currency at the wrapped native token address of the chain if
+// the currency is the native currency of the chain
+func (c *Currency) Unwrapped(chainID constants.ChainID) (*Token, error) {
+	wrapped, err := c.Wrapped(chainID)
+	if err != nil {
+		return nil, err
+	}
+	return &Token{
+		Currency: c,
+		ChainID:  chainID,
+		Address:  wrapped.Address,
+	}, nil
+}
+
 // Equals identifies whether A and B are equal
 func (c *Currency) Equals(other *Currency) bool {
 	return c == other ||
@@ -5,6 +5,7 @@ import (
 	"math/big"
 
 	"github.com/miraclesu/uniswap-sdk-go/constants"
+	"github.com/miraclesu/uniswap-sdk-go/number"
 	"github.com/miraclesu/uniswap-sdk-go/utils"
 )
 
@@ -40,6 +41,18 @@ func (c *CurrencyAmount) Raw() *big.Int {
 	return c.Numerator
 }
 
+// ToSignificantWithSymbol formats the amount as ToSignificant followed by the symbol of its currency, or placed as
+// the number.WithLocale option places currency symbols
+func (c *CurrencyAmount) ToSignificantWithSymbol(significantDigits uint, opt ...number.Option) string {
+	return c.ToSignificant(significantDigits, append([]number.Option{number.WithSymbol(c.Symbol)}, opt...)...)
+}
+
+// ToFixedWithSymbol formats the amount as ToFixed followed by the symbol of its currency, or placed as the
+// number.WithLocale option places currency symbols
+func (c *CurrencyAmount) ToFixedWithSymbol(decimalPlaces uint, opt ...number.Option) string {
+	return c.ToFixed(decimalPlaces, append([]number.Option{number.WithSymbol(c.Symbol)}, opt...)...)
+}
+
 // NewEther Helper that calls the constructor with the ETHER currency
 // @param amount ether amount in wei
 func NewEther(amount *big.Int) (*CurrencyAmount, error) {
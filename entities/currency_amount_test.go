@@ -0,0 +1,51 @@
+package entities
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+	"github.com/miraclesu/uniswap-sdk-go/number"
+)
+
+func TestCurrencyAmountWithSymbol(t *testing.T) {
+	ether, err := NewEther(big.NewInt(0).Mul(big.NewInt(12345678), big.NewInt(1e14)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	usdc, err := NewToken(constants.Mainnet, common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"), 6, "USDC", "USD Coin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokenAmount, err := NewTokenAmount(usdc, big.NewInt(1234567891))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dust, err := NewTokenAmount(usdc, big.NewInt(1234))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		Output string
+		Expect string
+	}{
+		{ether.ToFixedWithSymbol(2), "1234.57 ETH"},
+		{ether.ToFixedWithSymbol(2, number.WithLocale(number.EnUS)), "ETH\u00a01,234.57"},
+		{ether.ToFixedWithSymbol(2, number.WithLocale(number.DeDE)), "1.234,57\u00a0ETH"},
+		{ether.ToFixedWithSymbol(4, number.WithLocale(number.FrFR)), "1\u202f234,5678\u00a0ETH"},
+		{ether.ToFixedWithSymbol(2, number.WithLocale(number.EnUS), number.WithSymbol("US$")), "US$1,234.57"},
+		{tokenAmount.ToFixedWithSymbol(2, number.WithLocale(number.EnIN)), "USDC\u00a01,234.57"},
+		{dust.ToSignificantWithSymbol(2, number.WithLocale(number.JaJP), number.WithSymbol("$")), "$0.0012"},
+		// formatting without the symbol is unchanged
+		{tokenAmount.ToFixed(2), "1234.57"},
+	}
+	for i, test := range tests {
+		if test.Output != test.Expect {
+			t.Errorf("test #%d: expect[%q], but got[%q]", i, test.Expect, test.Output)
+		}
+	}
+}
@@ -2,7 +2,6 @@ package entities
 
 import (
 	"math/big"
-	"strings"
 
 	"github.com/shopspring/decimal"
 
@@ -13,8 +12,6 @@ import (
 // ZeroFraction zero fraction instance
 var ZeroFraction = NewFraction(constants.Zero, nil)
 
-const decimalSplitLength = 2
-
 // Fraction warps math franction
 type Fraction struct {
 	Numerator   *big.Int
@@ -119,39 +116,51 @@ func (f *Fraction) Divide(other *Fraction) *Fraction {
 
 // ToSignificant format output
 func (f *Fraction) ToSignificant(significantDigits uint, opt ...number.Option) string {
-	f.opts = number.New(number.WithGroupSeparator('\xA0'), number.WithRoundingMode(constants.RoundHalfUp))
+	f.opts = number.New(number.WithGroupSeparator(number.NoSeparator), number.WithRoundingMode(constants.RoundHalfUp))
 	f.opts.Apply(opt...)
 
-	d := decimal.NewFromBigInt(f.Numerator, 0).Div(decimal.NewFromBigInt(f.Denominator, 0))
-	if d.LessThan(decimal.New(1, 0)) {
-		significantDigits += countZerosAfterDecimalPoint(d.String())
+	r := f.rat()
+	if r.Cmp(big.NewRat(1, 1)) < 0 {
+		significantDigits += countZerosAfterDecimalPoint(r)
 	}
 	f.opts.Apply(number.WithRoundingPrecision(int(significantDigits)))
-	if v, err := number.DecimalRound(d, f.opts); err == nil {
-		d = v
+	return number.DecimalFormat(f.round(r), f.opts)
+}
+
+// rat returns the exact value of the fraction
+func (f *Fraction) rat() *big.Rat {
+	return new(big.Rat).SetFrac(f.Numerator, f.Denominator)
+}
+
+// round rounds the exact value of the fraction with the options, or truncates it to 16 decimal places if the rounding
+// mode is invalid
+func (f *Fraction) round(r *big.Rat) decimal.Decimal {
+	if d, err := number.RatRound(r, f.opts); err == nil {
+		return d
 	}
-	return number.DecimalFormat(d, f.opts)
+	return decimal.NewFromBigInt(f.Numerator, 0).Div(decimal.NewFromBigInt(f.Denominator, 0))
 }
 
-func countZerosAfterDecimalPoint(d string) uint {
-	grp := strings.Split(d, ".")
-	if len(grp) != decimalSplitLength {
+// countZerosAfterDecimalPoint returns the number of zeros between the decimal point and the first non zero digit of
+// the fractional part of r, or 0 if r is an integer
+func countZerosAfterDecimalPoint(r *big.Rat) uint {
+	frac := new(big.Rat).Abs(r)
+	frac.Sub(frac, new(big.Rat).SetInt(new(big.Int).Quo(frac.Num(), frac.Denom())))
+	if frac.Sign() == 0 {
 		return 0
 	}
-	for i, v := range grp[1] {
-		if v != '0' {
-			return uint(i)
-		}
+	var zeros uint
+	for frac.Mul(frac, big.NewRat(10, 1)).Cmp(big.NewRat(1, 1)) < 0 {
+		zeros++
 	}
-	return 0
+	return zeros
 }
 
 // ToFixed format output
 func (f *Fraction) ToFixed(decimalPlaces uint, opt ...number.Option) string {
-	f.opts = number.New(number.WithGroupSeparator('\xA0'), number.WithRoundingMode(constants.RoundHalfUp))
+	f.opts = number.New(number.WithGroupSeparator(number.NoSeparator), number.WithRoundingMode(constants.RoundHalfUp))
 	f.opts.Apply(opt...)
-	f.opts.Apply(number.WithDecimalPlaces(decimalPlaces))
+	f.opts.Apply(number.WithDecimalPlaces(decimalPlaces), number.WithRoundingPrecision(int(decimalPlaces)))
 
-	d := decimal.NewFromBigInt(f.Numerator, 0).Div(decimal.NewFromBigInt(f.Denominator, 0))
-	return number.DecimalFormat(d, f.opts)
+	return number.DecimalFormat(f.round(f.rat()), f.opts)
 }
@@ -3,6 +3,9 @@ package entities
 import (
 	"math/big"
 	"testing"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+	"github.com/miraclesu/uniswap-sdk-go/number"
 )
 
 func TestQuotient(t *testing.T) {
@@ -213,3 +216,137 @@ func TestToSignificant(t *testing.T) {
 		}
 	}
 }
+
+// the expected values are generated with the quantize of Python's decimal module at 200 digits of precision, whose
+// ROUND_* modes are the rounding modes of decimal.js, in the order of roundingModes, negative zeros are written as 0
+var (
+	roundingModes = []constants.Rounding{
+		constants.RoundDown,
+		constants.RoundHalfUp,
+		constants.RoundUp,
+		constants.RoundHalfEven,
+		constants.RoundHalfDown,
+		constants.RoundCeiling,
+		constants.RoundFloor,
+	}
+
+	// numerator, denominator, decimal places and the toFixed results
+	toFixedVectors = []struct {
+		Numerator   string
+		Denominator string
+		Format      uint
+		Output      [7]string
+	}{
+		{"5", "2", 0, [7]string{"2", "3", "3", "2", "2", "3", "2"}},
+		{"-5", "2", 0, [7]string{"-2", "-3", "-3", "-2", "-2", "-2", "-3"}},
+		{"7", "2", 0, [7]string{"3", "4", "4", "4", "3", "4", "3"}},
+		{"-7", "2", 0, [7]string{"-3", "-4", "-4", "-4", "-3", "-3", "-4"}},
+		{"-1", "2", 0, [7]string{"0", "-1", "-1", "0", "0", "0", "-1"}},
+		{"-2", "5", 0, [7]string{"0", "0", "-1", "0", "0", "0", "-1"}},
+		{"1", "8", 2, [7]string{"0.12", "0.13", "0.13", "0.12", "0.12", "0.13", "0.12"}},
+		{"-1", "8", 2, [7]string{"-0.12", "-0.13", "-0.13", "-0.12", "-0.12", "-0.12", "-0.13"}},
+		{"-1", "3", 0, [7]string{"0", "0", "-1", "0", "0", "0", "-1"}},
+		{"2", "3", 4, [7]string{"0.6666", "0.6667", "0.6667", "0.6667", "0.6667", "0.6667", "0.6666"}},
+		{"-2", "3", 4, [7]string{"-0.6666", "-0.6667", "-0.6667", "-0.6667", "-0.6667", "-0.6666", "-0.6667"}},
+		{"1", "3", 20, [7]string{"0.33333333333333333333", "0.33333333333333333333", "0.33333333333333333334", "0.33333333333333333333", "0.33333333333333333333", "0.33333333333333333334", "0.33333333333333333333"}},
+		{"-1", "3", 20, [7]string{"-0.33333333333333333333", "-0.33333333333333333333", "-0.33333333333333333334", "-0.33333333333333333333", "-0.33333333333333333333", "-0.33333333333333333333", "-0.33333333333333333334"}},
+		{"200000000000000001", "100000000000000000", 0, [7]string{"2", "2", "3", "2", "2", "3", "2"}},
+		{"-200000000000000001", "100000000000000000", 0, [7]string{"-2", "-2", "-3", "-2", "-2", "-2", "-3"}},
+		{"250000000000000000001", "100000000000000000000", 0, [7]string{"2", "3", "3", "3", "3", "3", "2"}},
+		{"-250000000000000000001", "100000000000000000000", 0, [7]string{"-2", "-3", "-3", "-3", "-3", "-2", "-3"}},
+		{"-249999999999999999999", "100000000000000000000", 0, [7]string{"-2", "-2", "-3", "-2", "-2", "-2", "-3"}},
+		{"12345678901234567895", "100000000000000000000", 19, [7]string{"0.1234567890123456789", "0.1234567890123456790", "0.1234567890123456790", "0.1234567890123456790", "0.1234567890123456789", "0.1234567890123456790", "0.1234567890123456789"}},
+		{"-12345678901234567895", "100000000000000000000", 19, [7]string{"-0.1234567890123456789", "-0.1234567890123456790", "-0.1234567890123456790", "-0.1234567890123456790", "-0.1234567890123456789", "-0.1234567890123456789", "-0.1234567890123456790"}},
+		{"-12345678901234567885", "100000000000000000000", 19, [7]string{"-0.1234567890123456788", "-0.1234567890123456789", "-0.1234567890123456789", "-0.1234567890123456788", "-0.1234567890123456788", "-0.1234567890123456788", "-0.1234567890123456789"}},
+		{"99999999999999999999", "100000000000000000000", 18, [7]string{"0.999999999999999999", "1.000000000000000000", "1.000000000000000000", "1.000000000000000000", "1.000000000000000000", "1.000000000000000000", "0.999999999999999999"}},
+		{"-99999999999999999999", "100000000000000000000", 18, [7]string{"-0.999999999999999999", "-1.000000000000000000", "-1.000000000000000000", "-1.000000000000000000", "-1.000000000000000000", "-0.999999999999999999", "-1.000000000000000000"}},
+		{"1", "200000000000000000000", 20, [7]string{"0.00000000000000000000", "0.00000000000000000001", "0.00000000000000000001", "0.00000000000000000000", "0.00000000000000000000", "0.00000000000000000001", "0.00000000000000000000"}},
+		{"-3", "200000000000000000000", 20, [7]string{"-0.00000000000000000001", "-0.00000000000000000002", "-0.00000000000000000002", "-0.00000000000000000002", "-0.00000000000000000001", "-0.00000000000000000001", "-0.00000000000000000002"}},
+		{"-1", "300000000000000000000", 20, [7]string{"0.00000000000000000000", "0.00000000000000000000", "-0.00000000000000000001", "0.00000000000000000000", "0.00000000000000000000", "0.00000000000000000000", "-0.00000000000000000001"}},
+		{"123456789012345678901234567", "1000", 2, [7]string{"123456789012345678901234.56", "123456789012345678901234.57", "123456789012345678901234.57", "123456789012345678901234.57", "123456789012345678901234.57", "123456789012345678901234.57", "123456789012345678901234.56"}},
+		{"-123456789012345678901234565", "1000", 2, [7]string{"-123456789012345678901234.56", "-123456789012345678901234.57", "-123456789012345678901234.57", "-123456789012345678901234.56", "-123456789012345678901234.56", "-123456789012345678901234.56", "-123456789012345678901234.57"}},
+	}
+
+	// numerator, denominator, significant digits and the toSignificantDigits results
+	toSignificantVectors = []struct {
+		Numerator   string
+		Denominator string
+		Format      uint
+		Output      [7]string
+	}{
+		{"1", "8", 2, [7]string{"0.12", "0.13", "0.13", "0.12", "0.12", "0.13", "0.12"}},
+		{"-1", "8", 2, [7]string{"-0.12", "-0.13", "-0.13", "-0.12", "-0.12", "-0.12", "-0.13"}},
+		{"3", "8", 2, [7]string{"0.37", "0.38", "0.38", "0.38", "0.37", "0.38", "0.37"}},
+		{"-3", "8", 2, [7]string{"-0.37", "-0.38", "-0.38", "-0.38", "-0.37", "-0.37", "-0.38"}},
+		{"1", "3", 5, [7]string{"0.33333", "0.33333", "0.33334", "0.33333", "0.33333", "0.33334", "0.33333"}},
+		{"-2", "3", 5, [7]string{"-0.66666", "-0.66667", "-0.66667", "-0.66667", "-0.66667", "-0.66666", "-0.66667"}},
+		{"125", "10000", 2, [7]string{"0.012", "0.013", "0.013", "0.012", "0.012", "0.013", "0.012"}},
+		{"-125", "10000", 2, [7]string{"-0.012", "-0.013", "-0.013", "-0.012", "-0.012", "-0.012", "-0.013"}},
+		{"135", "10000", 2, [7]string{"0.013", "0.014", "0.014", "0.014", "0.013", "0.014", "0.013"}},
+		{"-15", "1000000", 1, [7]string{"-0.00001", "-0.00002", "-0.00002", "-0.00002", "-0.00001", "-0.00001", "-0.00002"}},
+		{"-25", "1000000", 1, [7]string{"-0.00002", "-0.00003", "-0.00003", "-0.00002", "-0.00002", "-0.00002", "-0.00003"}},
+		{"99999999999999999999", "100000000000000000000", 3, [7]string{"0.999", "1", "1", "1", "1", "1", "0.999"}},
+		{"-99999999999999999999", "100000000000000000000", 3, [7]string{"-0.999", "-1", "-1", "-1", "-1", "-0.999", "-1"}},
+		{"1234567890123456785", "10000000000000000000", 18, [7]string{"0.123456789012345678", "0.123456789012345679", "0.123456789012345679", "0.123456789012345678", "0.123456789012345678", "0.123456789012345679", "0.123456789012345678"}},
+		{"-1234567890123456785", "10000000000000000000", 18, [7]string{"-0.123456789012345678", "-0.123456789012345679", "-0.123456789012345679", "-0.123456789012345678", "-0.123456789012345678", "-0.123456789012345678", "-0.123456789012345679"}},
+		{"-1234567890123456775", "10000000000000000000", 18, [7]string{"-0.123456789012345677", "-0.123456789012345678", "-0.123456789012345678", "-0.123456789012345678", "-0.123456789012345677", "-0.123456789012345677", "-0.123456789012345678"}},
+		{"1", "200000000000000000000", 1, [7]string{"0.000000000000000000005", "0.000000000000000000005", "0.000000000000000000005", "0.000000000000000000005", "0.000000000000000000005", "0.000000000000000000005", "0.000000000000000000005"}},
+		{"-3", "200000000000000000000", 1, [7]string{"-0.00000000000000000001", "-0.00000000000000000002", "-0.00000000000000000002", "-0.00000000000000000002", "-0.00000000000000000001", "-0.00000000000000000001", "-0.00000000000000000002"}},
+		{"123456789", "10000000000000000000000000000", 4, [7]string{"0.00000000000000000001234", "0.00000000000000000001235", "0.00000000000000000001235", "0.00000000000000000001235", "0.00000000000000000001235", "0.00000000000000000001235", "0.00000000000000000001234"}},
+		{"-123456785", "10000000000000000000000000000", 8, [7]string{"-0.000000000000000000012345678", "-0.000000000000000000012345679", "-0.000000000000000000012345679", "-0.000000000000000000012345678", "-0.000000000000000000012345678", "-0.000000000000000000012345678", "-0.000000000000000000012345679"}},
+	}
+)
+
+func mustFraction(t *testing.T, numerator, denominator string) (*big.Int, *big.Int) {
+	n, ok := big.NewInt(0).SetString(numerator, 10)
+	if !ok {
+		t.Fatalf("invalid numerator %s", numerator)
+	}
+	d, ok := big.NewInt(0).SetString(denominator, 10)
+	if !ok {
+		t.Fatalf("invalid denominator %s", denominator)
+	}
+	return n, d
+}
+
+func TestRoundingModes(t *testing.T) {
+	for _, test := range toFixedVectors {
+		n, d := mustFraction(t, test.Numerator, test.Denominator)
+		for i, rounding := range roundingModes {
+			output := NewFraction(n, d).ToFixed(test.Format, number.WithRoundingMode(rounding))
+			if output != test.Output[i] {
+				t.Errorf("%s/%s ToFixed(%d, %d): expect[%+v], but got[%+v]", test.Numerator, test.Denominator,
+					test.Format, rounding, test.Output[i], output)
+			}
+		}
+	}
+
+	for _, test := range toSignificantVectors {
+		n, d := mustFraction(t, test.Numerator, test.Denominator)
+		for i, rounding := range roundingModes {
+			output := NewFraction(n, d).ToSignificant(test.Format, number.WithRoundingMode(rounding))
+			if output != test.Output[i] {
+				t.Errorf("%s/%s ToSignificant(%d, %d): expect[%+v], but got[%+v]", test.Numerator, test.Denominator,
+					test.Format, rounding, test.Output[i], output)
+			}
+		}
+	}
+
+	// prices are rounded after being adjusted to the decimals of their currencies
+	{
+		base, _ := newCurrency(6, "BASE", "")
+		quote, _ := newCurrency(18, "QUOTE", "")
+		price := NewPrice(base, quote, big.NewInt(1000000), big.NewInt(0).Mul(big.NewInt(125), big.NewInt(1e16)))
+		for rounding, expect := range map[constants.Rounding]string{
+			constants.RoundHalfUp:   "1.3",
+			constants.RoundHalfEven: "1.2",
+			constants.RoundHalfDown: "1.2",
+			constants.RoundCeiling:  "1.3",
+			constants.RoundFloor:    "1.2",
+		} {
+			if output := price.ToFixed(1, number.WithRoundingMode(rounding)); output != expect {
+				t.Errorf("rounding %d: expect[%+v], but got[%+v]", rounding, expect, output)
+			}
+		}
+	}
+}
@@ -0,0 +1,501 @@
+package entities
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+	"github.com/miraclesu/uniswap-sdk-go/utils"
+)
+
+// The JSON schema of the entities, raw amounts are decimal strings, addresses are checksummed hex strings and chain
+// ids are numbers. The derived fields marked read-only are ignored by the unmarshalling, which recomputes them.
+//
+//	Currency    {"decimals": 18, "symbol": "ETH", "name": "Ether", "native": true}, native is omitted unless the native
+//	             currency of a registered chain, e.g. ETHER
+//	Token       {"chainId": 1, "address": "0xC02a...", "decimals": 18, "symbol": "WETH", "name": "Wrapped Ether",
+//	             "native": true, "buyFee": Percent, "sellFee": Percent}, native and the fees are optional
+//	TokenAmount {"token": Token, "amount": "1000"}
+//	Percent     {"numerator": "3", "denominator": "1000"}
+//	Price       {"baseCurrency": Currency, "quoteCurrency": Currency, "numerator": "2000", "denominator": "1000"}
+//	Protocol    {"name": "Uniswap V2", "factoryAddress": "0x5C69...", "initCodeHash": "0x96e8...",
+//	             "feeNumerator": "997", "feeDenominator": "1000", "liquiditySymbol": "UNI-V2",
+//	             "liquidityName": "Uniswap V2"}
+//	Pair        {"protocol": Protocol, "reserve0": TokenAmount, "reserve1": TokenAmount,
+//	             "address": read-only, "liquidityToken": read-only Token}
+//	Route       {"pairs": [Pair], "input": Token, "output": Token,
+//	             "path": read-only [Token], "midPrice": read-only Price}
+//	Trade       {"route": Route, "tradeType": "EXACT_INPUT" or "EXACT_OUTPUT", "inputAmount": TokenAmount,
+//	             "outputAmount": TokenAmount, "executionPrice": read-only Price, "nextMidPrice": read-only Price,
+//	             "priceImpact": read-only Percent, "estimatedGas": "150000", "gasCost": TokenAmount}
+//	             the input amount of exact input trades and the output amount of exact output trades are used to
+//	             reconstruct the trade, the gas fields are optional
+
+var (
+	// ErrInvalidJSON the JSON does not match the schema
+	ErrInvalidJSON = fmt.Errorf("invalid json")
+
+	tradeTypeNames = map[constants.TradeType]string{
+		constants.ExactInput:  "EXACT_INPUT",
+		constants.ExactOutput: "EXACT_OUTPUT",
+	}
+)
+
+func marshalDecimal(value *big.Int) string {
+	return value.String()
+}
+
+func unmarshalDecimal(value string) (*big.Int, error) {
+	result, ok := big.NewInt(0).SetString(value, 10)
+	if !ok {
+		return nil, ErrInvalidJSON
+	}
+	return result, nil
+}
+
+func unmarshalAddress(value string) (common.Address, error) {
+	address, err := utils.ParseAddress(value, utils.Lenient)
+	if err != nil {
+		return common.Address{}, ErrInvalidJSON
+	}
+	return address, nil
+}
+
+type currencyJSON struct {
+	Decimals int    `json:"decimals"`
+	Symbol   string `json:"symbol"`
+	Name     string `json:"name"`
+	Native   bool   `json:"native,omitempty"`
+}
+
+func marshalCurrency(currency *Currency) *currencyJSON {
+	return &currencyJSON{
+		Decimals: currency.Decimals,
+		Symbol:   currency.Symbol,
+		Name:     currency.Name,
+		Native:   currency.IsNative(),
+	}
+}
+
+func (c *currencyJSON) currency() (*Currency, error) {
+	if c.Native {
+		for _, chain := range Chains() {
+			if native := chain.NativeCurrency; native.Decimals == c.Decimals && native.Symbol == c.Symbol &&
+				native.Name == c.Name {
+				return native, nil
+			}
+		}
+		return nil, ErrInvalidJSON
+	}
+	return newCurrency(c.Decimals, c.Symbol, c.Name)
+}
+
+type tokenJSON struct {
+	ChainID constants.ChainID `json:"chainId"`
+	Address string            `json:"address"`
+	currencyJSON
+	BuyFee  *Percent `json:"buyFee,omitempty"`
+	SellFee *Percent `json:"sellFee,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (t *Token) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&tokenJSON{
+		ChainID:      t.ChainID,
+		Address:      t.Address.Hex(),
+		currencyJSON: *marshalCurrency(t.Currency),
+		BuyFee:       t.BuyFee,
+		SellFee:      t.SellFee,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *Token) UnmarshalJSON(data []byte) error {
+	var v tokenJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	address, err := unmarshalAddress(v.Address)
+	if err != nil {
+		return err
+	}
+	currency, err := v.currency()
+	if err != nil {
+		return err
+	}
+
+	token, err := (&Token{
+		Currency: currency,
+		ChainID:  v.ChainID,
+		Address:  address,
+	}).WithTransferFees(v.BuyFee, v.SellFee)
+	if err != nil {
+		return err
+	}
+	*t = *token
+	return nil
+}
+
+type tokenAmountJSON struct {
+	Token  *Token `json:"token"`
+	Amount string `json:"amount"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (t *TokenAmount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&tokenAmountJSON{
+		Token:  t.Token,
+		Amount: marshalDecimal(t.Raw()),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *TokenAmount) UnmarshalJSON(data []byte) error {
+	var v tokenAmountJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.Token == nil {
+		return ErrInvalidJSON
+	}
+	amount, err := unmarshalDecimal(v.Amount)
+	if err != nil {
+		return err
+	}
+	tokenAmount, err := NewTokenAmount(v.Token, amount)
+	if err != nil {
+		return err
+	}
+
+	*t = *tokenAmount
+	return nil
+}
+
+type fractionJSON struct {
+	Numerator   string `json:"numerator"`
+	Denominator string `json:"denominator"`
+}
+
+func marshalFraction(fraction *Fraction) fractionJSON {
+	return fractionJSON{
+		Numerator:   marshalDecimal(fraction.Numerator),
+		Denominator: marshalDecimal(fraction.Denominator),
+	}
+}
+
+func (f *fractionJSON) fraction() (numerator, denominator *big.Int, err error) {
+	numerator, err = unmarshalDecimal(f.Numerator)
+	if err != nil {
+		return nil, nil, err
+	}
+	denominator, err = unmarshalDecimal(f.Denominator)
+	if err != nil {
+		return nil, nil, err
+	}
+	if denominator.Sign() == 0 {
+		return nil, nil, ErrInvalidJSON
+	}
+	return numerator, denominator, nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (p *Percent) MarshalJSON() ([]byte, error) {
+	v := marshalFraction(p.Fraction)
+	return json.Marshal(&v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (p *Percent) UnmarshalJSON(data []byte) error {
+	var v fractionJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	numerator, denominator, err := v.fraction()
+	if err != nil {
+		return err
+	}
+
+	*p = *NewPercent(numerator, denominator)
+	return nil
+}
+
+type priceJSON struct {
+	BaseCurrency  *currencyJSON `json:"baseCurrency"`
+	QuoteCurrency *currencyJSON `json:"quoteCurrency"`
+	fractionJSON
+}
+
+// MarshalJSON implements json.Marshaler
+func (p *Price) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&priceJSON{
+		BaseCurrency:  marshalCurrency(p.BaseCurrency),
+		QuoteCurrency: marshalCurrency(p.QuoteCurrency),
+		fractionJSON:  marshalFraction(p.Fraction),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (p *Price) UnmarshalJSON(data []byte) error {
+	var v priceJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.BaseCurrency == nil || v.QuoteCurrency == nil {
+		return ErrInvalidJSON
+	}
+	baseCurrency, err := v.BaseCurrency.currency()
+	if err != nil {
+		return err
+	}
+	quoteCurrency, err := v.QuoteCurrency.currency()
+	if err != nil {
+		return err
+	}
+	numerator, denominator, err := v.fraction()
+	if err != nil {
+		return err
+	}
+
+	*p = *NewPrice(baseCurrency, quoteCurrency, denominator, numerator)
+	return nil
+}
+
+type protocolJSON struct {
+	Name            string        `json:"name"`
+	FactoryAddress  string        `json:"factoryAddress"`
+	InitCodeHash    hexutil.Bytes `json:"initCodeHash"`
+	FeeNumerator    string        `json:"feeNumerator"`
+	FeeDenominator  string        `json:"feeDenominator"`
+	LiquiditySymbol string        `json:"liquiditySymbol"`
+	LiquidityName   string        `json:"liquidityName"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (p *Protocol) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&protocolJSON{
+		Name:            p.Name,
+		FactoryAddress:  p.FactoryAddress.Hex(),
+		InitCodeHash:    p.InitCodeHash,
+		FeeNumerator:    marshalDecimal(p.FeeNumerator),
+		FeeDenominator:  marshalDecimal(p.FeeDenominator),
+		LiquiditySymbol: p.LiquiditySymbol,
+		LiquidityName:   p.LiquidityName,
+	})
+}
+
+// unmarshalProtocol returns the known protocol equal to the JSON, so the pair address cache is shared, or a new one
+func unmarshalProtocol(data []byte) (*Protocol, error) {
+	var v protocolJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	factoryAddress, err := unmarshalAddress(v.FactoryAddress)
+	if err != nil {
+		return nil, err
+	}
+	feeNumerator, err := unmarshalDecimal(v.FeeNumerator)
+	if err != nil {
+		return nil, err
+	}
+	feeDenominator, err := unmarshalDecimal(v.FeeDenominator)
+	if err != nil {
+		return nil, err
+	}
+	if feeDenominator.Sign() == 0 {
+		return nil, ErrInvalidJSON
+	}
+
+	protocol := NewProtocol(v.Name, factoryAddress, v.InitCodeHash, feeNumerator, feeDenominator).
+		WithLiquidityToken(v.LiquiditySymbol, v.LiquidityName)
+	for _, known := range knownProtocols {
+		if known.equals(protocol) {
+			return known, nil
+		}
+	}
+	for _, chain := range Chains() {
+		for _, known := range chain.Protocols {
+			if known.equals(protocol) {
+				return known, nil
+			}
+		}
+	}
+	return protocol, nil
+}
+
+func (p *Protocol) equals(other *Protocol) bool {
+	return p.Name == other.Name &&
+		p.FactoryAddress == other.FactoryAddress &&
+		bytes.Equal(p.InitCodeHash, other.InitCodeHash) &&
+		p.FeeNumerator.Cmp(other.FeeNumerator) == 0 &&
+		p.FeeDenominator.Cmp(other.FeeDenominator) == 0 &&
+		p.LiquiditySymbol == other.LiquiditySymbol &&
+		p.LiquidityName == other.LiquidityName
+}
+
+type pairJSON struct {
+	Protocol       json.RawMessage `json:"protocol"`
+	Reserve0       *TokenAmount    `json:"reserve0"`
+	Reserve1       *TokenAmount    `json:"reserve1"`
+	Address        string          `json:"address,omitempty"`
+	LiquidityToken *Token          `json:"liquidityToken,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (p *Pair) MarshalJSON() ([]byte, error) {
+	protocol, err := json.Marshal(p.Protocol)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(&pairJSON{
+		Protocol:       protocol,
+		Reserve0:       p.Reserve0(),
+		Reserve1:       p.Reserve1(),
+		Address:        p.GetAddress().Hex(),
+		LiquidityToken: p.LiquidityToken,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (p *Pair) UnmarshalJSON(data []byte) error {
+	var v pairJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.Reserve0 == nil || v.Reserve1 == nil {
+		return ErrInvalidJSON
+	}
+	protocol := UniswapV2
+	if len(v.Protocol) > 0 && !bytes.Equal(v.Protocol, []byte("null")) {
+		var err error
+		if protocol, err = unmarshalProtocol(v.Protocol); err != nil {
+			return err
+		}
+	}
+	pair, err := NewPairWithProtocol(v.Reserve0, v.Reserve1, protocol)
+	if err != nil {
+		return err
+	}
+
+	*p = *pair
+	return nil
+}
+
+type routeJSON struct {
+	Pairs    []*Pair  `json:"pairs"`
+	Input    *Token   `json:"input"`
+	Output   *Token   `json:"output"`
+	Path     []*Token `json:"path,omitempty"`
+	MidPrice *Price   `json:"midPrice,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler, only routes through pairs may be marshaled
+func (r *Route) MarshalJSON() ([]byte, error) {
+	if r.Pairs == nil {
+		return nil, ErrNotPair
+	}
+	return json.Marshal(&routeJSON{
+		Pairs:    r.Pairs,
+		Input:    r.Input,
+		Output:   r.Output,
+		Path:     r.Path,
+		MidPrice: r.MidPrice,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (r *Route) UnmarshalJSON(data []byte) error {
+	var v routeJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.Input == nil || v.Output == nil {
+		return ErrInvalidJSON
+	}
+	route, err := NewRoute(v.Pairs, v.Input, v.Output)
+	if err != nil {
+		return err
+	}
+
+	*r = *route
+	return nil
+}
+
+type tradeJSON struct {
+	Route          *Route       `json:"route"`
+	TradeType      string       `json:"tradeType"`
+	InputAmount    *TokenAmount `json:"inputAmount"`
+	OutputAmount   *TokenAmount `json:"outputAmount"`
+	ExecutionPrice *Price       `json:"executionPrice,omitempty"`
+	NextMidPrice   *Price       `json:"nextMidPrice,omitempty"`
+	PriceImpact    *Percent     `json:"priceImpact,omitempty"`
+	EstimatedGas   string       `json:"estimatedGas,omitempty"`
+	GasCost        *TokenAmount `json:"gasCost,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (t *Trade) MarshalJSON() ([]byte, error) {
+	tradeType, ok := tradeTypeNames[t.TradeType]
+	if !ok {
+		return nil, ErrInvalidJSON
+	}
+	v := &tradeJSON{
+		Route:          t.Route,
+		TradeType:      tradeType,
+		InputAmount:    t.inputAmount,
+		OutputAmount:   t.outputAmount,
+		ExecutionPrice: t.ExecutionPrice,
+		NextMidPrice:   t.NextMidPrice,
+		PriceImpact:    t.PriceImpact,
+		GasCost:        t.GasCost,
+	}
+	if t.EstimatedGas != nil {
+		v.EstimatedGas = marshalDecimal(t.EstimatedGas)
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+// nolint gocyclo
+func (t *Trade) UnmarshalJSON(data []byte) error {
+	var v tradeJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.Route == nil {
+		return ErrInvalidJSON
+	}
+
+	var trade *Trade
+	var err error
+	switch v.TradeType {
+	case tradeTypeNames[constants.ExactInput]:
+		if v.InputAmount == nil {
+			return ErrInvalidJSON
+		}
+		trade, err = ExactIn(v.Route, v.InputAmount)
+	case tradeTypeNames[constants.ExactOutput]:
+		if v.OutputAmount == nil {
+			return ErrInvalidJSON
+		}
+		trade, err = ExactOut(v.Route, v.OutputAmount)
+	default:
+		return ErrInvalidJSON
+	}
+	if err != nil {
+		return err
+	}
+	if v.EstimatedGas != "" {
+		if trade.EstimatedGas, err = unmarshalDecimal(v.EstimatedGas); err != nil {
+			return err
+		}
+	}
+	trade.GasCost = v.GasCost
+
+	*t = *trade
+	return nil
+}
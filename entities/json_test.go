@@ -0,0 +1,176 @@
+package entities
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+)
+
+func roundTrip(t *testing.T, in, out interface{}) string {
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		t.Fatalf("unmarshal %s: %v", data, err)
+	}
+	again, err := json.Marshal(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != string(again) {
+		t.Errorf("expect[%s], but got[%s]", data, again)
+	}
+	return string(data)
+}
+
+// nolint funlen
+func TestJSON(t *testing.T) {
+	dai, _ := NewToken(constants.Mainnet, common.HexToAddress("0x6b175474e89094c44da98b954eedeac495271d0f"), 18, "DAI", "Dai Stablecoin")
+	usdc, _ := NewToken(constants.Mainnet, common.HexToAddress("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"), 6, "USDC", "USD//C")
+	weth := WETH[constants.Mainnet]
+	ether := NewETHRToken(constants.Mainnet, weth.Address)
+	taxed, _ := NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000001"), 9, "TAX", "")
+	taxed, err := taxed.WithTransferFees(NewPercent(big.NewInt(2), big.NewInt(100)),
+		NewPercent(big.NewInt(3), big.NewInt(100)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pair_dai_usdc, _ := NewPair(mustTokenAmount(t, dai, 1000000), mustTokenAmount(t, usdc, 2000000))
+	pair_weth_dai, _ := NewPairWithProtocol(mustTokenAmount(t, weth, 1000000), mustTokenAmount(t, dai, 3000000), PancakeSwapV2)
+
+	// token
+	{
+		var output Token
+		data := roundTrip(t, dai, &output)
+		expect := `{"chainId":1,"address":"0x6B175474E89094C44Da98b954EedeAC495271d0F","decimals":18,"symbol":"DAI","name":"Dai Stablecoin"}`
+		if data != expect {
+			t.Errorf("expect[%+v], but got[%+v]", expect, data)
+		}
+		if !output.Equals(dai) || *output.Currency != *dai.Currency {
+			t.Errorf("expect[%+v], but got[%+v]", dai, output)
+		}
+
+		var native Token
+		roundTrip(t, ether, &native)
+		if native.Currency != ETHER {
+			t.Errorf("expect[%+v], but got[%+v]", ETHER, native.Currency)
+		}
+
+		var fee Token
+		roundTrip(t, taxed, &fee)
+		if !fee.HasTransferFees() || !fee.SellFee.EqualTo(taxed.SellFee.Fraction) {
+			t.Errorf("expect[%+v], but got[%+v]", taxed.SellFee, fee.SellFee)
+		}
+	}
+
+	// token amount
+	{
+		var output TokenAmount
+		data := roundTrip(t, mustTokenAmount(t, usdc, 123456789), &output)
+		if !strings.Contains(data, `"amount":"123456789"`) {
+			t.Errorf("expect decimal string amount, but got[%+v]", data)
+		}
+		if output.Raw().Int64() != 123456789 || output.Decimals != 6 {
+			t.Errorf("expect[%+v], but got[%+v]", 123456789, output.Raw())
+		}
+	}
+
+	// pair and route
+	{
+		var pair Pair
+		roundTrip(t, pair_weth_dai, &pair)
+		if pair.Protocol != PancakeSwapV2 {
+			t.Errorf("expect[%+v], but got[%+v]", PancakeSwapV2.Name, pair.Protocol.Name)
+		}
+		if !pair.LiquidityToken.Equals(pair_weth_dai.LiquidityToken) || pair.LiquidityToken.Symbol != "Cake-LP" {
+			t.Errorf("expect[%+v], but got[%+v]", pair_weth_dai.LiquidityToken, pair.LiquidityToken)
+		}
+
+		custom := NewProtocol("Custom", common.HexToAddress("0x0000000000000000000000000000000000000009"),
+			constants.InitCodeHash, big.NewInt(99), big.NewInt(100))
+		pair_custom, _ := NewPairWithProtocol(mustTokenAmount(t, dai, 100), mustTokenAmount(t, usdc, 100), custom)
+		roundTrip(t, pair_custom, &pair)
+		if pair.GetAddress() != pair_custom.GetAddress() || pair.Protocol.FeeNumerator.Int64() != 99 {
+			t.Errorf("expect[%+v], but got[%+v]", pair_custom.GetAddress().Hex(), pair.GetAddress().Hex())
+		}
+
+		route, _ := NewRoute([]*Pair{pair_weth_dai, pair_dai_usdc}, ETHER, usdc)
+		var output Route
+		roundTrip(t, route, &output)
+		if len(output.Path) != 3 || !output.Path[2].Equals(usdc) || output.Input.Currency != ETHER {
+			t.Errorf("expect[%+v], but got[%+v]", route.Path, output.Path)
+		}
+		if !output.MidPrice.EqualTo(route.MidPrice.Fraction) {
+			t.Errorf("expect[%+v], but got[%+v]", route.MidPrice.ToSignificant(6), output.MidPrice.ToSignificant(6))
+		}
+	}
+
+	// price and percent
+	{
+		var price Price
+		roundTrip(t, pair_dai_usdc.Token0Price(), &price)
+		if price.ToSignificant(6) != pair_dai_usdc.Token0Price().ToSignificant(6) {
+			t.Errorf("expect[%+v], but got[%+v]", pair_dai_usdc.Token0Price().ToSignificant(6), price.ToSignificant(6))
+		}
+
+		var percent Percent
+		data := roundTrip(t, NewPercent(big.NewInt(3), big.NewInt(1000)), &percent)
+		if data != `{"numerator":"3","denominator":"1000"}` || percent.ToFixed(1) != "0.3" {
+			t.Errorf("expect[%+v], but got[%+v]", "0.3", percent.ToFixed(1))
+		}
+	}
+
+	// trade
+	{
+		route, _ := NewRoute([]*Pair{pair_weth_dai, pair_dai_usdc}, ETHER, usdc)
+		for _, trade := range []*Trade{
+			mustTrade(t)(ExactIn(route, mustTokenAmount(t, ether, 1000))),
+			mustTrade(t)(ExactOut(route, mustTokenAmount(t, usdc, 1000))),
+		} {
+			trade.EstimatedGas = big.NewInt(150000)
+			trade.GasCost = mustTokenAmount(t, usdc, 5)
+			var output Trade
+			roundTrip(t, trade, &output)
+			if output.TradeType != trade.TradeType ||
+				!output.InputAmount().Equals(trade.InputAmount()) || !output.OutputAmount().Equals(trade.OutputAmount()) {
+				t.Errorf("expect[%+v %+v], but got[%+v %+v]", trade.InputAmount().Raw(), trade.OutputAmount().Raw(),
+					output.InputAmount().Raw(), output.OutputAmount().Raw())
+			}
+			if !output.PriceImpact.EqualTo(trade.PriceImpact.Fraction) || output.EstimatedGas.Int64() != 150000 {
+				t.Errorf("expect[%+v], but got[%+v]", trade.PriceImpact.ToSignificant(6), output.PriceImpact.ToSignificant(6))
+			}
+		}
+	}
+
+	// errors
+	{
+		var token Token
+		if err := json.Unmarshal([]byte(`{"chainId":1,"address":"0x1234","decimals":18}`), &token); err != ErrInvalidJSON {
+			t.Errorf("expect[%+v], but got[%+v]", ErrInvalidJSON, err)
+		}
+		var amount TokenAmount
+		if err := json.Unmarshal([]byte(`{"token":{"chainId":1,"address":"0x6B175474E89094C44Da98b954EedeAC495271d0F","decimals":18},"amount":"1.5"}`), &amount); err != ErrInvalidJSON {
+			t.Errorf("expect[%+v], but got[%+v]", ErrInvalidJSON, err)
+		}
+		var trade Trade
+		if err := json.Unmarshal([]byte(`{"tradeType":"EXACT_INPUT"}`), &trade); err != ErrInvalidJSON {
+			t.Errorf("expect[%+v], but got[%+v]", ErrInvalidJSON, err)
+		}
+	}
+}
+
+func mustTrade(t *testing.T) func(*Trade, error) *Trade {
+	return func(trade *Trade, err error) *Trade {
+		if err != nil {
+			t.Fatal(err)
+		}
+		return trade
+	}
+}
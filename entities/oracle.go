@@ -0,0 +1,100 @@
+package entities
+
+import (
+	"fmt"
+	"math/big"
+)
+
+var (
+	// ErrInvalidObservation the observations are taken at the same timestamp
+	ErrInvalidObservation = fmt.Errorf("invalid observation")
+
+	// _Q112 is the resolution of the UQ112x112 fixed point numbers
+	_Q112 = big.NewInt(0).Lsh(big.NewInt(1), 112)
+	// _Uint224Modulus and _Uint256Modulus wrap the overflowed values like the contract does
+	_Uint224Modulus = big.NewInt(0).Lsh(big.NewInt(1), 224)
+	_Uint256Modulus = big.NewInt(0).Lsh(big.NewInt(1), 256)
+)
+
+// Observation is a snapshot of the cumulative prices of a pair, as the price0CumulativeLast,
+// price1CumulativeLast and blockTimestampLast stored by the pair contract
+type Observation struct {
+	// Price0Cumulative is the sum of the UQ112x112 token0 price multiplied by the seconds it lasted, modulo 2**256
+	Price0Cumulative *big.Int
+	// Price1Cumulative is the sum of the UQ112x112 token1 price multiplied by the seconds it lasted, modulo 2**256
+	Price1Cumulative *big.Int
+	// BlockTimestamp is the block timestamp modulo 2**32
+	BlockTimestamp uint32
+}
+
+// NewObservation creates an Observation
+// @param price0Cumulative the price0CumulativeLast of the pair
+// @param price1Cumulative the price1CumulativeLast of the pair
+// @param blockTimestamp the block timestamp of the observation, reduced modulo 2**32
+func NewObservation(price0Cumulative, price1Cumulative *big.Int, blockTimestamp uint64) *Observation {
+	return &Observation{
+		Price0Cumulative: big.NewInt(0).Mod(price0Cumulative, _Uint256Modulus),
+		Price1Cumulative: big.NewInt(0).Mod(price1Cumulative, _Uint256Modulus),
+		BlockTimestamp:   uint32(blockTimestamp),
+	}
+}
+
+// fraction encodes numerator / denominator as UQ112x112, same as FixedPoint.fraction
+func fraction(numerator, denominator *big.Int) *big.Int {
+	encoded := big.NewInt(0).Lsh(numerator, 112)
+	encoded.Div(encoded, denominator)
+	return encoded.Mod(encoded, _Uint224Modulus)
+}
+
+// CurrentObservation returns the counterfactual cumulative prices at the block timestamp, assuming the reserves of
+// the pair have not changed since the last observation, same as UniswapV2OracleLibrary.currentCumulativePrices
+// @param last the last observation stored by the pair, taken with the reserves of the pair
+// @param blockTimestamp the current block timestamp, reduced modulo 2**32
+func (p *Pair) CurrentObservation(last *Observation, blockTimestamp uint64) (*Observation, error) {
+	if p.Reserve0().Raw().Sign() == 0 || p.Reserve1().Raw().Sign() == 0 {
+		return nil, ErrInsufficientReserves
+	}
+
+	observation := NewObservation(last.Price0Cumulative, last.Price1Cumulative, blockTimestamp)
+	// overflow is desired
+	timeElapsed := big.NewInt(int64(observation.BlockTimestamp - last.BlockTimestamp))
+	if timeElapsed.Sign() == 0 {
+		return observation, nil
+	}
+
+	price0 := fraction(p.Reserve1().Raw(), p.Reserve0().Raw())
+	observation.Price0Cumulative.Add(observation.Price0Cumulative, price0.Mul(price0, timeElapsed))
+	observation.Price0Cumulative.Mod(observation.Price0Cumulative, _Uint256Modulus)
+
+	price1 := fraction(p.Reserve0().Raw(), p.Reserve1().Raw())
+	observation.Price1Cumulative.Add(observation.Price1Cumulative, price1.Mul(price1, timeElapsed))
+	observation.Price1Cumulative.Mod(observation.Price1Cumulative, _Uint256Modulus)
+	return observation, nil
+}
+
+// TWAP returns the time weighted average prices of token0 and token1 between the two observations,
+// the overflows of the timestamps and the cumulative prices are handled as the contract does,
+// so the observations must be taken less than 2**32 seconds apart
+// @param start the earlier observation
+// @param end the later observation
+func (p *Pair) TWAP(start, end *Observation) (token0Price, token1Price *Price, err error) {
+	// overflow is desired
+	timeElapsed := big.NewInt(int64(end.BlockTimestamp - start.BlockTimestamp))
+	if timeElapsed.Sign() == 0 {
+		return nil, nil, ErrInvalidObservation
+	}
+
+	average := func(startCumulative, endCumulative *big.Int) *big.Int {
+		// overflow is desired
+		diff := big.NewInt(0).Sub(endCumulative, startCumulative)
+		diff.Mod(diff, _Uint256Modulus)
+		diff.Div(diff, timeElapsed)
+		return diff.Mod(diff, _Uint224Modulus)
+	}
+
+	token0Price = NewPrice(p.Token0().Currency, p.Token1().Currency, big.NewInt(0).Set(_Q112),
+		average(start.Price0Cumulative, end.Price0Cumulative))
+	token1Price = NewPrice(p.Token1().Currency, p.Token0().Currency, big.NewInt(0).Set(_Q112),
+		average(start.Price1Cumulative, end.Price1Cumulative))
+	return token0Price, token1Price, nil
+}
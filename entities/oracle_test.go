@@ -0,0 +1,109 @@
+package entities
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+)
+
+// nolint funlen
+func TestPair_TWAP(t *testing.T) {
+	token0, _ := NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000001"), 18, "t0", "")
+	token1, _ := NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000002"), 18, "t1", "")
+	tokenAmount_0_1000, _ := NewTokenAmount(token0, big.NewInt(1000))
+	tokenAmount_0_4000, _ := NewTokenAmount(token0, big.NewInt(4000))
+	tokenAmount_1_2000, _ := NewTokenAmount(token1, big.NewInt(2000))
+	pair_0_1, _ := NewPair(tokenAmount_0_1000, tokenAmount_1_2000)
+	pair_0_1_cheap, _ := NewPair(tokenAmount_0_4000, tokenAmount_1_2000)
+
+	// accumulates the UQ112x112 prices
+	{
+		start := NewObservation(big.NewInt(0), big.NewInt(0), 1000)
+		output, err := pair_0_1.CurrentObservation(start, 1010)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect := big.NewInt(0).Lsh(big.NewInt(20), 112)
+		if output.Price0Cumulative.Cmp(expect) != 0 {
+			t.Errorf("expect[%+v], but got[%+v]", expect, output.Price0Cumulative)
+		}
+		expect = big.NewInt(0).Lsh(big.NewInt(5), 112)
+		if output.Price1Cumulative.Cmp(expect) != 0 {
+			t.Errorf("expect[%+v], but got[%+v]", expect, output.Price1Cumulative)
+		}
+		if output.BlockTimestamp != 1010 {
+			t.Errorf("expect[%+v], but got[%+v]", 1010, output.BlockTimestamp)
+		}
+
+		// unchanged in the same block
+		same, err := pair_0_1.CurrentObservation(output, 1010)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if same.Price0Cumulative.Cmp(output.Price0Cumulative) != 0 {
+			t.Errorf("expect[%+v], but got[%+v]", output.Price0Cumulative, same.Price0Cumulative)
+		}
+	}
+
+	// averages the prices between observations
+	{
+		start := NewObservation(big.NewInt(0), big.NewInt(0), 1000)
+		middle, _ := pair_0_1.CurrentObservation(start, 1030)
+		end, _ := pair_0_1_cheap.CurrentObservation(middle, 1040)
+		token0Price, token1Price, err := pair_0_1.TWAP(start, end)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// (2 * 30 + 0.5 * 10) / 40
+		expect := "1.625"
+		if output := token0Price.ToSignificant(4); output != expect {
+			t.Errorf("expect[%+v], but got[%+v]", expect, output)
+		}
+		// (0.5 * 30 + 2 * 10) / 40
+		expect = "0.875"
+		if output := token1Price.ToSignificant(3); output != expect {
+			t.Errorf("expect[%+v], but got[%+v]", expect, output)
+		}
+		if !token0Price.BaseCurrency.Equals(token0.Currency) || !token0Price.QuoteCurrency.Equals(token1.Currency) {
+			t.Errorf("expect[%+v/%+v], but got[%+v/%+v]", token0, token1, token0Price.BaseCurrency, token0Price.QuoteCurrency)
+		}
+	}
+
+	// handles the overflow of the timestamps and the cumulative prices
+	{
+		maxUint32 := uint64(1<<32 - 1)
+		nearMax := big.NewInt(0).Sub(_Uint256Modulus, big.NewInt(0).Lsh(big.NewInt(1), 112))
+		start := NewObservation(nearMax, nearMax, maxUint32-4)
+		end, err := pair_0_1.CurrentObservation(start, maxUint32+6)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if end.BlockTimestamp != 5 {
+			t.Errorf("expect[%+v], but got[%+v]", 5, end.BlockTimestamp)
+		}
+		// 2**256 - 2**112 + 2 * 2**112 * 10 wraps around
+		expect := big.NewInt(0).Lsh(big.NewInt(19), 112)
+		if end.Price0Cumulative.Cmp(expect) != 0 {
+			t.Errorf("expect[%+v], but got[%+v]", expect, end.Price0Cumulative)
+		}
+		token0Price, _, err := pair_0_1.TWAP(start, end)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if output := token0Price.ToSignificant(6); output != "2" {
+			t.Errorf("expect[%+v], but got[%+v]", "2", output)
+		}
+	}
+
+	// throws for observations at the same timestamp
+	{
+		start := NewObservation(big.NewInt(0), big.NewInt(0), 1000)
+		_, _, output := pair_0_1.TWAP(start, start)
+		if output != ErrInvalidObservation {
+			t.Errorf("expect[%+v], but got[%+v]", ErrInvalidObservation, output)
+		}
+	}
+}
@@ -12,10 +12,7 @@ import (
 )
 
 var (
-	_PairAddressCache = &PairAddressCache{
-		lk:      new(sync.RWMutex),
-		address: make(map[common.Address]map[common.Address]common.Address, 16),
-	}
+	_PairAddressCache = UniswapV2.addresses
 
 	// ErrInvalidLiquidity invalid liquidity
 	ErrInvalidLiquidity = fmt.Errorf("invalid liquidity")
@@ -41,11 +38,14 @@ func NewTokenAmounts(tokenAmountA, tokenAmountB *TokenAmount) (TokenAmounts, err
 	return TokenAmounts{tokenAmountB, tokenAmountA}, nil
 }
 
-// PairAddressCache warps pair address cache
+// PairAddressCache warps pair address cache of a factory
 type PairAddressCache struct {
 	lk *sync.RWMutex
 	// token0 address : token1 address : pair address
 	address map[common.Address]map[common.Address]common.Address
+
+	factory      common.Address
+	initCodeHash []byte
 }
 
 // GetAddress returns contract address
@@ -57,7 +57,7 @@ func (p *PairAddressCache) GetAddress(addressA, addressB common.Address) common.
 		p.lk.RUnlock()
 		p.lk.Lock()
 		defer p.lk.Unlock()
-		addr := getCreate2Address(addressA, addressB)
+		addr := getCreate2Address(p.factory, p.initCodeHash, addressA, addressB)
 		p.address[addressA] = map[common.Address]common.Address{
 			addressB: addr,
 		}
@@ -69,7 +69,7 @@ func (p *PairAddressCache) GetAddress(addressA, addressB common.Address) common.
 		p.lk.RUnlock()
 		p.lk.Lock()
 		defer p.lk.Unlock()
-		addr := getCreate2Address(addressA, addressB)
+		addr := getCreate2Address(p.factory, p.initCodeHash, addressA, addressB)
 		pairAddresses[addressB] = addr
 		return addr
 	}
@@ -78,10 +78,10 @@ func (p *PairAddressCache) GetAddress(addressA, addressB common.Address) common.
 	return pairAddress
 }
 
-func getCreate2Address(addressA, addressB common.Address) common.Address {
+func getCreate2Address(factory common.Address, initCodeHash []byte, addressA, addressB common.Address) common.Address {
 	var salt [32]byte
 	copy(salt[:], crypto.Keccak256(append(addressA.Bytes(), addressB.Bytes()...)))
-	return crypto.CreateAddress2(constants.FactoryAddress, salt, constants.InitCodeHash)
+	return crypto.CreateAddress2(factory, salt, initCodeHash)
 }
 
 // Pair warps uniswap pair
@@ -89,10 +89,17 @@ type Pair struct {
 	LiquidityToken *Token
 	// sorted tokens
 	TokenAmounts
+	// the protocol the pair is deployed by
+	Protocol *Protocol
 }
 
-// NewPair creates Pair
+// NewPair creates a Uniswap V2 Pair
 func NewPair(tokenAmountA, tokenAmountB *TokenAmount) (*Pair, error) {
+	return NewPairWithProtocol(tokenAmountA, tokenAmountB, UniswapV2)
+}
+
+// NewPairWithProtocol creates a Pair deployed by the protocol
+func NewPairWithProtocol(tokenAmountA, tokenAmountB *TokenAmount, protocol *Protocol) (*Pair, error) {
 	tokenAmounts, err := NewTokenAmounts(tokenAmountA, tokenAmountB)
 	if err != nil {
 		return nil, err
@@ -100,15 +107,21 @@ func NewPair(tokenAmountA, tokenAmountB *TokenAmount) (*Pair, error) {
 
 	pair := &Pair{
 		TokenAmounts: tokenAmounts,
+		Protocol:     protocol,
 	}
 	pair.LiquidityToken, err = NewToken(tokenAmountA.Token.ChainID, pair.GetAddress(),
-		constants.Decimals18, constants.Univ2Symbol, constants.Univ2Name)
+		constants.Decimals18, protocol.LiquiditySymbol, protocol.LiquidityName)
 	return pair, err
 }
 
+// GetPairAddress returns the contract address of the Uniswap V2 pair for tokenA and tokenB, in any order
+func GetPairAddress(tokenA, tokenB *Token) (common.Address, error) {
+	return UniswapV2.GetPairAddress(tokenA, tokenB)
+}
+
 // GetAddress returns a contract's address for a pair
 func (p *Pair) GetAddress() common.Address {
-	return _PairAddressCache.GetAddress(p.TokenAmounts[0].Token.Address, p.TokenAmounts[1].Token.Address)
+	return p.Protocol.addresses.GetAddress(p.TokenAmounts[0].Token.Address, p.TokenAmounts[1].Token.Address)
 }
 
 // InvolvesToken Returns true if the token is either token0 or token1
@@ -177,105 +190,198 @@ func (p *Pair) ReserveOf(token *Token) (*TokenAmount, error) {
 	return p.Reserve1(), nil
 }
 
+// swapAmounts the amounts of a swap through a pair, the amounts received and sent by the pair differ from the
+// amounts sent and received by the trader if the tokens tax transfers
+type swapAmounts struct {
+	inputAmount  *TokenAmount
+	outputAmount *TokenAmount
+	pairInput    *TokenAmount
+	pairOutput   *TokenAmount
+}
+
 // GetOutputAmount returns OutputAmount and a Pair for the InputAmout
+// the transfer fees of the tokens are taxed on the InputAmount sent to the pair and the OutputAmount sent by the pair
 func (p *Pair) GetOutputAmount(inputAmount *TokenAmount) (*TokenAmount, *Pair, error) {
+	return p.getOutputAmountFromPool(inputAmount, false)
+}
+
+// getOutputAmountFromPool is GetOutputAmount, the InputAmount is not taxed by the sell fee if it is sent by the
+// previous pool of a route, which taxed it by the buy fee
+func (p *Pair) getOutputAmountFromPool(inputAmount *TokenAmount, fromPool bool) (*TokenAmount, *Pair, error) {
+	swap, err := p.exactInSwap(inputAmount, fromPool)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pair, err := p.nextPair(swap.pairInput, swap.pairOutput)
+	if err != nil {
+		return nil, nil, err
+	}
+	return swap.outputAmount, pair, nil
+}
+
+// getOutputAmount returns OutputAmount for the InputAmout without computing the next Pair
+func (p *Pair) getOutputAmount(inputAmount *TokenAmount, fromPool bool) (*TokenAmount, error) {
+	swap, err := p.exactInSwap(inputAmount, fromPool)
+	if err != nil {
+		return nil, err
+	}
+	return swap.outputAmount, nil
+}
+
+// exactInSwap returns the amounts of swapping the InputAmount, fromPool is true if the InputAmount is sent by the
+// previous pool of a route, a transfer from pool to pool is only taxed once, by the buy fee as it leaves the pool
+func (p *Pair) exactInSwap(inputAmount *TokenAmount, fromPool bool) (*swapAmounts, error) {
 	if !p.InvolvesToken(inputAmount.Token) {
-		return nil, nil, ErrDiffToken
+		return nil, ErrDiffToken
 	}
 
 	if p.Reserve0().Raw().Cmp(constants.Zero) == 0 ||
 		p.Reserve1().Raw().Cmp(constants.Zero) == 0 {
-		return nil, nil, ErrInsufficientReserves
+		return nil, ErrInsufficientReserves
 	}
 
-	inputReserve, err := p.ReserveOf(inputAmount.Token)
-	if err != nil {
-		return nil, nil, err
+	inputReserve, outputReserve := p.Reserve0(), p.Reserve1()
+	if inputAmount.Token.Equals(p.Token1()) {
+		inputReserve, outputReserve = outputReserve, inputReserve
 	}
-	token := p.Token0()
-	if inputAmount.Token.Equals(p.Token0()) {
-		token = p.Token1()
+
+	sellFee := inputReserve.Token.SellFee
+	if fromPool {
+		sellFee = nil
 	}
-	outputReserve, err := p.ReserveOf(token)
+	pairInput, err := NewTokenAmount(inputReserve.Token, afterTransferFee(inputAmount.Raw(), sellFee))
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
-
-	inputAmountWithFee := big.NewInt(0).Mul(inputAmount.Raw(), constants.B997)
+	inputAmountWithFee := big.NewInt(0).Mul(pairInput.Raw(), p.Protocol.FeeNumerator)
 	numerator := big.NewInt(0).Mul(inputAmountWithFee, outputReserve.Raw())
-	denominator := big.NewInt(0).Add(big.NewInt(0).Mul(inputReserve.Raw(), constants.B1000), inputAmountWithFee)
-	outputAmount, err := NewTokenAmount(token, big.NewInt(0).Div(numerator, denominator))
+	denominator := big.NewInt(0).Add(big.NewInt(0).Mul(inputReserve.Raw(), p.Protocol.FeeDenominator), inputAmountWithFee)
+	pairOutput, err := NewTokenAmount(outputReserve.Token, big.NewInt(0).Div(numerator, denominator))
 	if err != nil {
-		return nil, nil, err
+		return nil, err
+	}
+	outputAmount, err := NewTokenAmount(outputReserve.Token, afterTransferFee(pairOutput.Raw(), outputReserve.Token.BuyFee))
+	if err != nil {
+		return nil, err
 	}
 	if outputAmount.Raw().Cmp(constants.Zero) == 0 {
-		return nil, nil, ErrInsufficientInputAmount
+		return nil, ErrInsufficientInputAmount
 	}
+	return &swapAmounts{
+		inputAmount:  inputAmount,
+		outputAmount: outputAmount,
+		pairInput:    pairInput,
+		pairOutput:   pairOutput,
+	}, nil
+}
+
+// GetInputAmount returns InputAmout and a Pair for the OutputAmount
+// the transfer fees of the tokens are taxed on the InputAmount sent to the pair and the OutputAmount sent by the pair
+func (p *Pair) GetInputAmount(outputAmount *TokenAmount) (*TokenAmount, *Pair, error) {
+	return p.getInputAmountFromPool(outputAmount, false)
+}
 
-	tokenAmountA, err := inputAmount.Add(inputReserve)
+// getInputAmountFromPool is GetInputAmount, the InputAmount is not taxed by the sell fee if it is sent by the
+// previous pool of a route, which taxed it by the buy fee
+func (p *Pair) getInputAmountFromPool(outputAmount *TokenAmount, fromPool bool) (*TokenAmount, *Pair, error) {
+	swap, err := p.exactOutSwap(outputAmount, fromPool)
 	if err != nil {
 		return nil, nil, err
 	}
-	tokenAmountB, err := outputReserve.Subtract(outputAmount)
+
+	pair, err := p.nextPair(swap.pairInput, swap.pairOutput)
 	if err != nil {
 		return nil, nil, err
 	}
-	pair, err := NewPair(tokenAmountA, tokenAmountB)
+	return swap.inputAmount, pair, nil
+}
+
+// getInputAmount returns InputAmout for the OutputAmount without computing the next Pair
+func (p *Pair) getInputAmount(outputAmount *TokenAmount, fromPool bool) (*TokenAmount, error) {
+	swap, err := p.exactOutSwap(outputAmount, fromPool)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
-	return outputAmount, pair, nil
+	return swap.inputAmount, nil
 }
 
-// GetInputAmount returns InputAmout and a Pair for the OutputAmount
-func (p *Pair) GetInputAmount(outputAmount *TokenAmount) (*TokenAmount, *Pair, error) {
+// exactOutSwap returns the amounts of swapping for the OutputAmount, fromPool is true if the InputAmount is sent by
+// the previous pool of a route, see exactInSwap
+// nolint gocyclo
+func (p *Pair) exactOutSwap(outputAmount *TokenAmount, fromPool bool) (*swapAmounts, error) {
 	if !p.InvolvesToken(outputAmount.Token) {
-		return nil, nil, ErrDiffToken
+		return nil, ErrDiffToken
 	}
 
-	outputReserve, err := p.ReserveOf(outputAmount.Token)
+	outputReserve, inputReserve := p.Reserve0(), p.Reserve1()
+	if outputAmount.Token.Equals(p.Token1()) {
+		outputReserve, inputReserve = inputReserve, outputReserve
+	}
+	amount, err := beforeTransferFee(outputAmount.Raw(), outputReserve.Token.BuyFee)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
+	}
+	pairOutput, err := NewTokenAmount(outputReserve.Token, amount)
+	if err != nil {
+		return nil, err
 	}
 	if p.Reserve0().Raw().Cmp(constants.Zero) == 0 ||
 		p.Reserve1().Raw().Cmp(constants.Zero) == 0 ||
-		outputAmount.Raw().Cmp(outputReserve.Raw()) >= 0 {
-		return nil, nil, ErrInsufficientReserves
+		pairOutput.Raw().Cmp(outputReserve.Raw()) >= 0 {
+		return nil, ErrInsufficientReserves
 	}
 
-	token := p.Token0()
-	if outputAmount.Token.Equals(p.Token0()) {
-		token = p.Token1()
+	numerator := big.NewInt(0).Mul(inputReserve.Raw(), pairOutput.Raw())
+	numerator.Mul(numerator, p.Protocol.FeeDenominator)
+	denominator := big.NewInt(0).Sub(outputReserve.Raw(), pairOutput.Raw())
+	denominator.Mul(denominator, p.Protocol.FeeNumerator)
+	amount = big.NewInt(0).Div(numerator, denominator)
+	amount.Add(amount, constants.One)
+	pairInput, err := NewTokenAmount(inputReserve.Token, amount)
+	if err != nil {
+		return nil, err
+	}
+	sellFee := inputReserve.Token.SellFee
+	if fromPool {
+		sellFee = nil
 	}
-	inputReserve, err := p.ReserveOf(token)
+	amount, err = beforeTransferFee(pairInput.Raw(), sellFee)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
-
-	numerator := big.NewInt(0).Mul(inputReserve.Raw(), outputAmount.Raw())
-	numerator.Mul(numerator, constants.B1000)
-	denominator := big.NewInt(0).Sub(outputReserve.Raw(), outputAmount.Raw())
-	denominator.Mul(denominator, constants.B997)
-	amount := big.NewInt(0).Div(numerator, denominator)
-	amount.Add(amount, constants.One)
-	inputAmount, err := NewTokenAmount(token, amount)
+	inputAmount, err := NewTokenAmount(inputReserve.Token, amount)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
+	return &swapAmounts{
+		inputAmount:  inputAmount,
+		outputAmount: outputAmount,
+		pairInput:    pairInput,
+		pairOutput:   pairOutput,
+	}, nil
+}
 
-	tokenAmountA, err := inputAmount.Add(inputReserve)
+// nextPair returns the Pair after swapping the InputAmount for the OutputAmount
+func (p *Pair) nextPair(inputAmount, outputAmount *TokenAmount) (*Pair, error) {
+	inputReserve, err := p.ReserveOf(inputAmount.Token)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
-	tokenAmountB, err := outputReserve.Subtract(outputAmount)
+	outputReserve, err := p.ReserveOf(outputAmount.Token)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
-	pair, err := NewPair(tokenAmountA, tokenAmountB)
+
+	tokenAmountA, err := inputReserve.Add(inputAmount)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
+	}
+	tokenAmountB, err := outputReserve.Subtract(outputAmount)
+	if err != nil {
+		return nil, err
 	}
-	return inputAmount, pair, nil
+	return NewPairWithProtocol(tokenAmountA, tokenAmountB, p.Protocol)
 }
 
 // GetLiquidityMinted returns liquidity minted TokenAmount
@@ -0,0 +1,231 @@
+package entities
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+)
+
+type tokenKey struct {
+	chainID constants.ChainID
+	address common.Address
+}
+
+func newTokenKey(token *Token) tokenKey {
+	return tokenKey{chainID: token.ChainID, address: token.Address}
+}
+
+// PairGraph indexes pairs by token adjacency, so that the best trade search only walks the pairs involving the
+// current token instead of all the pairs.
+// It returns the same trades in the same order as BestTradeExactIn and BestTradeExactOut for the same pairs.
+type PairGraph struct {
+	pairs []*Pair
+	// token : indexes of the pairs involving the token, in increasing order
+	adjacency map[tokenKey][]int
+}
+
+// NewPairGraph creates a PairGraph, the pairs must not be modified afterwards
+func NewPairGraph(pairs []*Pair) *PairGraph {
+	g := &PairGraph{
+		pairs:     pairs,
+		adjacency: make(map[tokenKey][]int, len(pairs)),
+	}
+	for i, pair := range pairs {
+		for _, token := range []*Token{pair.Token0(), pair.Token1()} {
+			key := newTokenKey(token)
+			g.adjacency[key] = append(g.adjacency[key], i)
+		}
+	}
+	return g
+}
+
+// Pairs returns the pairs of the graph
+func (g *PairGraph) Pairs() []*Pair {
+	return g.pairs
+}
+
+// graphSearch holds the state of a depth first best trade search
+type graphSearch struct {
+	*PairGraph
+	options    *BestTradeOptions
+	used       []bool
+	path       []*Pair
+	bestTrades []*Trade
+}
+
+func (g *PairGraph) newSearch(options *BestTradeOptions) (*graphSearch, error) {
+	if options == nil {
+		options = NewDefaultBestTradeOptions()
+	}
+
+	if len(g.pairs) == 0 {
+		return nil, ErrInvalidPairs
+	}
+	if options.MaxHops <= 0 {
+		return nil, ErrInvalidOption
+	}
+
+	return &graphSearch{
+		PairGraph: g,
+		options:   options,
+		used:      make([]bool, len(g.pairs)),
+		path:      make([]*Pair, 0, options.MaxHops),
+	}, nil
+}
+
+/**
+ * Returns the top `maxNumResults` trades that go from an input token amount to an output token, making at most
+ * `maxHops` hops, see BestTradeExactIn.
+ * @param currencyAmountIn exact amount of input currency to spend
+ * @param currencyOut the desired currency out
+ * @param options the best trade options
+ */
+func (g *PairGraph) BestTradeExactIn(currencyAmountIn *TokenAmount, currencyOut Asset, options *BestTradeOptions) ([]*Trade, error) {
+	s, err := g.newSearch(options)
+	if err != nil {
+		return nil, err
+	}
+	tokenOut, err := currencyOut.Wrapped(currencyAmountIn.Token.ChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.exactIn(currencyAmountIn, currencyOut, tokenOut, currencyAmountIn, s.options.MaxHops); err != nil {
+		return nil, err
+	}
+	return s.bestTrades, nil
+}
+
+func (s *graphSearch) exactIn(amountIn *TokenAmount, currencyOut Asset, tokenOut *Token, originalAmountIn *TokenAmount, maxHops int) error {
+	for _, i := range s.adjacency[newTokenKey(amountIn.Token)] {
+		if s.used[i] {
+			continue
+		}
+		pair := s.pairs[i]
+		if pair.Reserve0().EqualTo(ZeroFraction) || pair.Reserve1().EqualTo(ZeroFraction) {
+			continue
+		}
+
+		amountOut, err := pair.getOutputAmount(amountIn, len(s.path) > 0)
+		if err != nil {
+			// input too low
+			if err == ErrInsufficientInputAmount {
+				continue
+			}
+			return err
+		}
+
+		// we have arrived at the output token, so this is the final trade of one of the paths
+		if amountOut.Token.Equals(tokenOut) {
+			pairs := make([]*Pair, len(s.path)+1)
+			copy(pairs, s.path)
+			pairs[len(s.path)] = pair
+			if err := s.insert(pairs, originalAmountIn.Token, currencyOut, originalAmountIn, constants.ExactInput); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// otherwise, consider all the other paths that lead from this token as long as we have not exceeded maxHops
+		if maxHops > 1 && len(s.pairs)-len(s.path) > 1 {
+			s.used[i] = true
+			s.path = append(s.path, pair)
+			err := s.exactIn(amountOut, currencyOut, tokenOut, originalAmountIn, maxHops-1)
+			s.path = s.path[:len(s.path)-1]
+			s.used[i] = false
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+/**
+ * Returns the top `maxNumResults` trades that go from an input token to an output token amount, making at most
+ * `maxHops` hops, see BestTradeExactOut.
+ * @param currencyIn the currency to spend
+ * @param currencyAmountOut the exact amount of currency out
+ * @param options the best trade options
+ */
+func (g *PairGraph) BestTradeExactOut(currencyIn Asset, currencyAmountOut *TokenAmount, options *BestTradeOptions) ([]*Trade, error) {
+	s, err := g.newSearch(options)
+	if err != nil {
+		return nil, err
+	}
+	tokenIn, err := currencyIn.Wrapped(currencyAmountOut.Token.ChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.exactOut(currencyIn, tokenIn, currencyAmountOut, currencyAmountOut, s.options.MaxHops); err != nil {
+		return nil, err
+	}
+	return s.bestTrades, nil
+}
+
+func (s *graphSearch) exactOut(currencyIn Asset, tokenIn *Token, amountOut, originalAmountOut *TokenAmount, maxHops int) error {
+	for _, i := range s.adjacency[newTokenKey(amountOut.Token)] {
+		if s.used[i] {
+			continue
+		}
+		pair := s.pairs[i]
+		if pair.Reserve0().EqualTo(ZeroFraction) || pair.Reserve1().EqualTo(ZeroFraction) {
+			continue
+		}
+
+		// the input of the pair is sent by the previous pair unless it is the input token
+		inputToken := pair.Token0()
+		if amountOut.Token.Equals(inputToken) {
+			inputToken = pair.Token1()
+		}
+		amountIn, err := pair.getInputAmount(amountOut, !inputToken.Equals(tokenIn))
+		if err != nil {
+			// not enough liquidity in this pair
+			if err == ErrInsufficientReserves {
+				continue
+			}
+			return err
+		}
+
+		// we have arrived at the input token, so this is the first trade of one of the paths
+		// s.path holds the following pairs in reverse order
+		if amountIn.Token.Equals(tokenIn) {
+			pairs := make([]*Pair, len(s.path)+1)
+			pairs[0] = pair
+			for j := range s.path {
+				pairs[len(s.path)-j] = s.path[j]
+			}
+			if err := s.insert(pairs, currencyIn, originalAmountOut.Token, originalAmountOut, constants.ExactOutput); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// otherwise, consider all the other paths that arrive at this token as long as we have not exceeded maxHops
+		if maxHops > 1 && len(s.pairs)-len(s.path) > 1 {
+			s.used[i] = true
+			s.path = append(s.path, pair)
+			err := s.exactOut(currencyIn, tokenIn, amountIn, originalAmountOut, maxHops-1)
+			s.path = s.path[:len(s.path)-1]
+			s.used[i] = false
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *graphSearch) insert(pairs []*Pair, input, output Asset, amount *TokenAmount, tradeType constants.TradeType) error {
+	route, err := NewRoute(pairs, input, output)
+	if err != nil {
+		return err
+	}
+	trade, err := NewTrade(route, amount, tradeType)
+	if err != nil {
+		return err
+	}
+	s.bestTrades, err = s.options.insert(s.bestTrades, trade)
+	return err
+}
@@ -0,0 +1,172 @@
+package entities
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+)
+
+// randomPairs creates numPairs pairs among numTokens tokens, deterministic for the seed
+func randomPairs(tb testing.TB, seed int64, numTokens, numPairs int) ([]*Token, []*Pair) {
+	r := rand.New(rand.NewSource(seed))
+
+	tokens := make([]*Token, numTokens)
+	for i := range tokens {
+		var address common.Address
+		r.Read(address[:])
+		token, err := NewToken(constants.Mainnet, address, 18, fmt.Sprintf("t%d", i), "")
+		if err != nil {
+			tb.Fatal(err)
+		}
+		tokens[i] = token
+	}
+
+	pairs := make([]*Pair, 0, numPairs)
+	exists := make(map[[2]int]bool, numPairs)
+	for len(pairs) < numPairs {
+		a, b := r.Intn(numTokens), r.Intn(numTokens)
+		if a == b || exists[[2]int{a, b}] || exists[[2]int{b, a}] {
+			continue
+		}
+		exists[[2]int{a, b}] = true
+
+		tokenAmountA, _ := NewTokenAmount(tokens[a], big.NewInt(r.Int63n(1e6)+1e3))
+		tokenAmountB, _ := NewTokenAmount(tokens[b], big.NewInt(r.Int63n(1e6)+1e3))
+		pair, err := NewPair(tokenAmountA, tokenAmountB)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		pairs = append(pairs, pair)
+	}
+	return tokens, pairs
+}
+
+func assertSameTrades(t *testing.T, expect, output []*Trade) {
+	t.Helper()
+	if len(expect) != len(output) {
+		t.Fatalf("expect[%+v] trades, but got[%+v]", len(expect), len(output))
+	}
+	for i := range expect {
+		if len(expect[i].Route.Pairs) != len(output[i].Route.Pairs) {
+			t.Fatalf("test #%d: expect[%+v] pairs, but got[%+v]", i, len(expect[i].Route.Pairs), len(output[i].Route.Pairs))
+		}
+		for j := range expect[i].Route.Pairs {
+			if expect[i].Route.Pairs[j] != output[i].Route.Pairs[j] {
+				t.Errorf("test #%d#%d: expect[%+v], but got[%+v]", i, j, expect[i].Route.Pairs[j], output[i].Route.Pairs[j])
+			}
+		}
+		if !expect[i].InputAmount().Equals(output[i].InputAmount()) || !expect[i].OutputAmount().Equals(output[i].OutputAmount()) {
+			t.Errorf("test #%d: expect[%+v -> %+v], but got[%+v -> %+v]", i, expect[i].InputAmount().Raw(),
+				expect[i].OutputAmount().Raw(), output[i].InputAmount().Raw(), output[i].OutputAmount().Raw())
+		}
+	}
+}
+
+func TestPairGraph(t *testing.T) {
+	tokens, pairs := randomPairs(t, 1, 30, 150)
+	graph := NewPairGraph(pairs)
+	options := &BestTradeOptions{MaxNumResults: 5, MaxHops: 3}
+
+	for i := 1; i < len(tokens); i++ {
+		amountIn, _ := NewTokenAmount(tokens[0], big.NewInt(1000))
+		expect, err := BestTradeExactIn(pairs, amountIn, tokens[i], options, nil, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		output, err := graph.BestTradeExactIn(amountIn, tokens[i], options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertSameTrades(t, expect, output)
+
+		amountOut, _ := NewTokenAmount(tokens[i], big.NewInt(1000))
+		expect, err = BestTradeExactOut(pairs, tokens[0], amountOut, options, nil, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		output, err = graph.BestTradeExactOut(tokens[0], amountOut, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertSameTrades(t, expect, output)
+	}
+
+	// throws with empty pairs
+	{
+		amountIn, _ := NewTokenAmount(tokens[0], big.NewInt(1000))
+		_, output := NewPairGraph(nil).BestTradeExactIn(amountIn, tokens[1], nil)
+		if output != ErrInvalidPairs {
+			t.Errorf("expect[%+v], but got[%+v]", ErrInvalidPairs, output)
+		}
+		// throws with max hops of 0
+		_, output = graph.BestTradeExactIn(amountIn, tokens[1], &BestTradeOptions{})
+		if output != ErrInvalidOption {
+			t.Errorf("expect[%+v], but got[%+v]", ErrInvalidOption, output)
+		}
+	}
+
+	// works for ETHER currency input and output
+	{
+		token0, _ := NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000001"), 18, "t0", "")
+		tokenAmount_0_1000, _ := NewTokenAmount(token0, big.NewInt(1000))
+		tokenAmount_weth_1000, _ := NewTokenAmount(WETH[constants.Mainnet], big.NewInt(1000))
+		pair_weth_0, _ := NewPair(tokenAmount_weth_1000, tokenAmount_0_1000)
+		graph := NewPairGraph([]*Pair{pair_weth_0})
+
+		etherAmount, _ := NewEtherAmount(constants.Mainnet, big.NewInt(100))
+		result, err := graph.BestTradeExactIn(etherAmount, token0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(result) != 1 || result[0].InputAmount().Currency != ETHER {
+			t.Errorf("expect ETHER input, but got[%+v]", result)
+		}
+
+		tokenAmount_0_100, _ := NewTokenAmount(token0, big.NewInt(100))
+		result, err = graph.BestTradeExactIn(tokenAmount_0_100, ETHER, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(result) != 1 || result[0].OutputAmount().Currency != ETHER {
+			t.Errorf("expect ETHER output, but got[%+v]", result)
+		}
+	}
+}
+
+func BenchmarkBestTradeExactIn(b *testing.B) {
+	for _, size := range []struct{ tokens, pairs int }{{50, 100}, {200, 1000}} {
+		tokens, pairs := randomPairs(b, 1, size.tokens, size.pairs)
+		amountIn, _ := NewTokenAmount(tokens[0], big.NewInt(1000))
+		options := NewDefaultBestTradeOptions()
+
+		b.Run(fmt.Sprintf("pairs=%d", size.pairs), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := BestTradeExactIn(pairs, amountIn, tokens[1], options, nil, nil, nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkPairGraph_BestTradeExactIn(b *testing.B) {
+	for _, size := range []struct{ tokens, pairs int }{{50, 100}, {200, 1000}, {1000, 5000}} {
+		tokens, pairs := randomPairs(b, 1, size.tokens, size.pairs)
+		amountIn, _ := NewTokenAmount(tokens[0], big.NewInt(1000))
+		options := NewDefaultBestTradeOptions()
+		graph := NewPairGraph(pairs)
+
+		b.Run(fmt.Sprintf("pairs=%d", size.pairs), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := graph.BestTradeExactIn(amountIn, tokens[1], options); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
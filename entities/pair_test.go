@@ -72,6 +72,17 @@ func TestPair(t *testing.T) {
 		if output.String() != expect {
 			t.Errorf("expect[%+v], but got[%+v]", expect, output)
 		}
+
+		// sorts the tokens
+		for _, tokens := range [][2]*Token{{USDC, DAI}, {DAI, USDC}} {
+			output, err := GetPairAddress(tokens[0], tokens[1])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if output.String() != expect {
+				t.Errorf("expect[%+v], but got[%+v]", expect, output)
+			}
+		}
 	}
 
 	{
@@ -375,3 +386,123 @@ func TestPair(t *testing.T) {
 		}
 	}
 }
+
+// nolint funlen
+func TestPairTransferFees(t *testing.T) {
+	token0, _ := NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000001"), 18, "t0", "")
+	token1, _ := NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000002"), 18, "t1", "")
+	// 5% on buys and 10% on sells
+	token0, err := token0.WithTransferFees(NewPercent(big.NewInt(5), big.NewInt(100)),
+		NewPercent(big.NewInt(10), big.NewInt(100)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokenAmount_0_1000, _ := NewTokenAmount(token0, big.NewInt(1000))
+	tokenAmount_1_1000, _ := NewTokenAmount(token1, big.NewInt(1000))
+	pair, _ := NewPair(tokenAmount_0_1000, tokenAmount_1_1000)
+
+	tests := []struct {
+		name     string
+		exactIn  bool
+		amount   *TokenAmount
+		expect   string
+		reserve0 string
+		reserve1 string
+	}{
+		// the pair receives 90 of 100 sold
+		{"sells the taxed token", true, mustTokenAmount(t, token0, 100), "82", "1090", "918"},
+		// the buyer receives 86 of 90 bought
+		{"buys the taxed token", true, mustTokenAmount(t, token1, 100), "86", "910", "1100"},
+		{"buys the exact taxed token", false, mustTokenAmount(t, token0, 86), "100", "910", "1100"},
+		{"sells the taxed token for the exact output", false, mustTokenAmount(t, token1, 82), "99", "1090", "918"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var output *TokenAmount
+			var nextPair *Pair
+			var err error
+			if tt.exactIn {
+				output, nextPair, err = pair.GetOutputAmount(tt.amount)
+			} else {
+				output, nextPair, err = pair.GetInputAmount(tt.amount)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if output.Raw().String() != tt.expect {
+				t.Errorf("expect[%+v], but got[%+v]", tt.expect, output.Raw())
+			}
+			if nextPair.Reserve0().Raw().String() != tt.reserve0 || nextPair.Reserve1().Raw().String() != tt.reserve1 {
+				t.Errorf("expect[%+v %+v], but got[%+v %+v]", tt.reserve0, tt.reserve1,
+					nextPair.Reserve0().Raw(), nextPair.Reserve1().Raw())
+			}
+		})
+	}
+
+	// the minimal amount before the fee
+	{
+		fee := NewPercent(big.NewInt(3), big.NewInt(7))
+		for amount := int64(0); amount < 100; amount++ {
+			output, err := beforeTransferFee(big.NewInt(amount), fee)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if afterTransferFee(output, fee).Int64() < amount {
+				t.Errorf("expect at least[%+v], but got[%+v]", amount, afterTransferFee(output, fee))
+			}
+			if output.Sign() > 0 && afterTransferFee(big.NewInt(0).Sub(output, constants.One), fee).Int64() >= amount {
+				t.Errorf("expect minimal amount before fee of [%+v], but got[%+v]", amount, output)
+			}
+		}
+	}
+}
+
+func TestToken_WithTransferFees(t *testing.T) {
+	token, _ := NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000001"), 18, "t0", "")
+	tests := []struct {
+		name   string
+		fee    *Percent
+		expect error
+	}{
+		{"no fee", nil, nil},
+		{"zero", NewPercent(big.NewInt(0), big.NewInt(100)), nil},
+		{"below one", NewPercent(big.NewInt(99), big.NewInt(100)), nil},
+		{"one", NewPercent(big.NewInt(100), big.NewInt(100)), ErrInvalidTransferFee},
+		{"above one", NewPercent(big.NewInt(101), big.NewInt(100)), ErrInvalidTransferFee},
+		{"negative", NewPercent(big.NewInt(-1), big.NewInt(100)), ErrInvalidTransferFee},
+		{"negative denominator", NewPercent(big.NewInt(-1), big.NewInt(-100)), ErrInvalidTransferFee},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := token.WithTransferFees(tt.fee, nil); err != tt.expect {
+				t.Errorf("expect[%+v], but got[%+v]", tt.expect, err)
+			}
+			if _, err := token.WithTransferFees(nil, tt.fee); err != tt.expect {
+				t.Errorf("expect[%+v], but got[%+v]", tt.expect, err)
+			}
+		})
+	}
+
+	// the fees are set on a copy, the token itself is left untouched
+	fee := NewPercent(big.NewInt(1), big.NewInt(100))
+	taxed, err := token.WithTransferFees(fee, fee)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if taxed == token || taxed.BuyFee != fee || taxed.SellFee != fee || !taxed.Equals(token) {
+		t.Errorf("wrong taxed token[%+v]", taxed)
+	}
+	if token.HasTransferFees() {
+		t.Errorf("expect token untouched, but got[%+v]", token)
+	}
+}
+
+func mustTokenAmount(t *testing.T, token *Token, amount int64) *TokenAmount {
+	tokenAmount, err := NewTokenAmount(token, big.NewInt(amount))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tokenAmount
+}
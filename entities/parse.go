@@ -0,0 +1,113 @@
+package entities
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+	"github.com/miraclesu/uniswap-sdk-go/number"
+)
+
+const (
+	// maxParseExponent bounds the exponent of parsed amounts, way beyond any uint256 amount
+	maxParseExponent = 1000
+	// maxUint256Digits is the number of digits of the max uint256
+	maxUint256Digits = 78
+)
+
+var (
+	// ErrInvalidAmount the amount is not a decimal number
+	ErrInvalidAmount = fmt.Errorf("invalid amount")
+	// ErrNegativeAmount the amount is negative
+	ErrNegativeAmount = fmt.Errorf("negative amount")
+	// ErrExcessPrecision the amount has more decimal places than the currency and no rounding mode is given
+	ErrExcessPrecision = fmt.Errorf("excess precision")
+	// ErrAmountOverflow the raw amount exceeds uint256
+	ErrAmountOverflow = fmt.Errorf("amount overflows uint256")
+)
+
+// parseRawAmount parses the human readable amount into the raw amount of the decimals
+func parseRawAmount(value string, decimals int, rounding []constants.Rounding) (*big.Int, error) {
+	value, err := removeGroupSeparators(strings.TrimSpace(value))
+	if err != nil {
+		return nil, err
+	}
+	d, err := decimal.NewFromString(value)
+	if err != nil {
+		return nil, ErrInvalidAmount
+	}
+	if d.Exponent() < -maxParseExponent || d.Exponent() > maxParseExponent {
+		return nil, ErrInvalidAmount
+	}
+	if d.Sign() < 0 {
+		return nil, ErrNegativeAmount
+	}
+
+	d = d.Shift(int32(decimals))
+	if d.Exponent() < 0 && !d.Equal(d.Truncate(0)) {
+		if len(rounding) == 0 {
+			return nil, ErrExcessPrecision
+		}
+		d, err = number.DecimalRound(d, number.New(number.WithRoundingMode(rounding[0]), number.WithRoundingPrecision(0)))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if d.Sign() != 0 && d.Exponent() > 0 && len(d.Coefficient().String())+int(d.Exponent()) > maxUint256Digits {
+		return nil, ErrAmountOverflow
+	}
+
+	amount := d.BigInt()
+	if amount.Cmp(constants.SolidityTypeMaxima[constants.Uint256]) > 0 {
+		return nil, ErrAmountOverflow
+	}
+	return amount, nil
+}
+
+// removeGroupSeparators removes the thousands separators of the integer part, which must group 3 digits
+func removeGroupSeparators(value string) (string, error) {
+	integer := value
+	if i := strings.IndexAny(value, ".eE"); i >= 0 {
+		integer = value[:i]
+	}
+	if !strings.Contains(integer, ",") {
+		return value, nil
+	}
+
+	groups := strings.Split(strings.TrimLeft(integer, "+-"), ",")
+	for i, group := range groups {
+		if (i == 0 && (len(group) == 0 || len(group) > 3)) || (i > 0 && len(group) != 3) {
+			return "", ErrInvalidAmount
+		}
+	}
+	return strings.ReplaceAll(integer, ",", "") + value[len(integer):], nil
+}
+
+// ParseCurrencyAmount parses a human readable amount such as "1,234.5" or "1.2345e3" of the currency.
+// Amounts with more decimal places than the currency are rejected unless a rounding mode is given.
+// @param currency the currency of the amount
+// @param value the decimal string of the amount
+// @param rounding the optional rounding mode of the excess decimal places
+func ParseCurrencyAmount(currency *Currency, value string, rounding ...constants.Rounding) (*CurrencyAmount, error) {
+	amount, err := parseRawAmount(value, currency.Decimals, rounding)
+	if err != nil {
+		return nil, err
+	}
+	return NewCurrencyAmount(currency, amount)
+}
+
+// ParseTokenAmount parses a human readable amount such as "1,234.5" or "1.2345e3" of the token.
+// Amounts with more decimal places than the token are rejected unless a rounding mode is given.
+// @param token the token of the amount
+// @param value the decimal string of the amount
+// @param rounding the optional rounding mode of the excess decimal places
+func ParseTokenAmount(token *Token, value string, rounding ...constants.Rounding) (*TokenAmount, error) {
+	amount, err := parseRawAmount(value, token.Decimals, rounding)
+	if err != nil {
+		return nil, err
+	}
+	return NewTokenAmount(token, amount)
+}
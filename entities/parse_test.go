@@ -0,0 +1,85 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+	"github.com/miraclesu/uniswap-sdk-go/number"
+)
+
+// nolint funlen
+func TestParseTokenAmount(t *testing.T) {
+	token, err := NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000001"), 6, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		Name     string
+		Value    string
+		Rounding []constants.Rounding
+		Expect   string
+		Err      error
+	}{
+		{Name: "integer", Value: "1", Expect: "1000000"},
+		{Name: "decimal", Value: " 1.5 ", Expect: "1500000"},
+		{Name: "leading dot", Value: ".000001", Expect: "1"},
+		{Name: "thousands separators", Value: "1,234,567.25", Expect: "1234567250000"},
+		{Name: "scientific notation", Value: "1.25e3", Expect: "1250000000"},
+		{Name: "negative exponent", Value: "25E-6", Expect: "25"},
+		{Name: "zero", Value: "0", Expect: "0"},
+		{Name: "invalid", Value: "1.2.3", Err: ErrInvalidAmount},
+		{Name: "empty", Value: "", Err: ErrInvalidAmount},
+		{Name: "misplaced separator", Value: "12,34", Err: ErrInvalidAmount},
+		{Name: "separator in fraction", Value: "1.234,5", Err: ErrInvalidAmount},
+		{Name: "huge exponent", Value: "1e100000", Err: ErrInvalidAmount},
+		{Name: "negative", Value: "-1", Err: ErrNegativeAmount},
+		{Name: "excess precision", Value: "0.0000015", Err: ErrExcessPrecision},
+		{Name: "round down", Value: "0.0000015", Rounding: []constants.Rounding{constants.RoundDown}, Expect: "1"},
+		{Name: "round half up", Value: "0.0000015", Rounding: []constants.Rounding{constants.RoundHalfUp}, Expect: "2"},
+		{Name: "round up", Value: "0.0000011", Rounding: []constants.Rounding{constants.RoundUp}, Expect: "2"},
+		{Name: "invalid rounding", Value: "0.0000011", Rounding: []constants.Rounding{-1}, Err: number.ErrInvalidRM},
+		{Name: "overflow", Value: "1e72", Err: ErrAmountOverflow},
+		{Name: "overflow max uint256", Value: "115792089237316195423570985008687907853269984665640564039457584007913129.639936", Err: ErrAmountOverflow},
+		{Name: "max uint256", Value: "115792089237316195423570985008687907853269984665640564039457584007913129.639935",
+			Expect: "115792089237316195423570985008687907853269984665640564039457584007913129639935"},
+	}
+	for i, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			amount, err := ParseTokenAmount(token, tests[i].Value, tests[i].Rounding...)
+			if err != test.Err {
+				t.Fatalf("expect[%+v], but got[%+v]", test.Err, err)
+			}
+			if err != nil {
+				return
+			}
+			if amount.Raw().String() != test.Expect {
+				t.Errorf("expect[%+v], but got[%+v]", test.Expect, amount.Raw())
+			}
+			if !amount.Token.Equals(token) {
+				t.Errorf("expect[%+v], but got[%+v]", token, amount.Token)
+			}
+		})
+	}
+}
+
+func TestParseCurrencyAmount(t *testing.T) {
+	amount, err := ParseCurrencyAmount(ETHER, "1.5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	{
+		expect := "1500000000000000000"
+		if output := amount.Raw().String(); output != expect {
+			t.Errorf("expect[%+v], but got[%+v]", expect, output)
+		}
+	}
+	{
+		expect := ErrExcessPrecision
+		if _, output := ParseCurrencyAmount(ETHER, "1e-19"); output != expect {
+			t.Errorf("expect[%+v], but got[%+v]", expect, output)
+		}
+	}
+}
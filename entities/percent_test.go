@@ -72,3 +72,18 @@ func TestPercent_ToFixed(t *testing.T) {
 		})
 	}
 }
+
+// the percent is the fraction scaled by 100, so it rounds as the fraction does
+func TestPercent_RoundingModes(t *testing.T) {
+	for _, test := range toFixedVectors {
+		n, d := mustFraction(t, test.Numerator, test.Denominator)
+		d.Mul(d, big.NewInt(100))
+		for i, rounding := range roundingModes {
+			output := NewPercent(n, d).ToFixed(test.Format, number.WithRoundingMode(rounding))
+			if output != test.Output[i] {
+				t.Errorf("%s/%s ToFixed(%d, %d): expect[%+v], but got[%+v]", test.Numerator, test.Denominator,
+					test.Format, rounding, test.Output[i], output)
+			}
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package entities
+
+import (
+	"fmt"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+)
+
+var (
+	// ErrNotPair the route goes through pools which are not pairs
+	ErrNotPair = fmt.Errorf("pool is not a pair")
+)
+
+// Pool is a pool of two tokens trades can be routed through, e.g. a Pair, a concentrated liquidity pool
+// or a quote of a market maker
+type Pool interface {
+	// ChainID returns the chain ID of the tokens in the pool
+	ChainID() constants.ChainID
+	// Token0 and Token1 return the tokens in the pool, Token0 sorts before Token1
+	Token0() *Token
+	Token1() *Token
+	// InvolvesToken returns true if the token is either Token0 or Token1
+	InvolvesToken(token *Token) bool
+	// PriceOf returns the mid price of the token in terms of the other token in the pool
+	PriceOf(token *Token) (*Price, error)
+	// SwapExactIn returns the output amount for the input amount and the pool after the swap, it returns
+	// ErrInsufficientInputAmount if the output amount is zero and ErrInsufficientReserves if the pool can not
+	// fill the swap
+	SwapExactIn(inputAmount *TokenAmount) (*TokenAmount, Pool, error)
+	// SwapExactOut returns the input amount for the output amount and the pool after the swap, it returns
+	// ErrInsufficientReserves if the pool can not fill the swap
+	SwapExactOut(outputAmount *TokenAmount) (*TokenAmount, Pool, error)
+}
+
+var _ Pool = (*Pair)(nil)
+
+// SwapExactIn implements Pool, it is GetOutputAmount returning the next pair as a Pool
+func (p *Pair) SwapExactIn(inputAmount *TokenAmount) (*TokenAmount, Pool, error) {
+	outputAmount, pair, err := p.GetOutputAmount(inputAmount)
+	if err != nil {
+		return nil, nil, err
+	}
+	return outputAmount, pair, nil
+}
+
+// SwapExactOut implements Pool, it is GetInputAmount returning the next pair as a Pool
+func (p *Pair) SwapExactOut(outputAmount *TokenAmount) (*TokenAmount, Pool, error) {
+	inputAmount, pair, err := p.GetInputAmount(outputAmount)
+	if err != nil {
+		return nil, nil, err
+	}
+	return inputAmount, pair, nil
+}
+
+// swapExactIn swaps the input amount through the pool as a hop of a route, fromPool is true if the input amount is
+// sent by the previous pool of the route, so that the transfer between the pairs is only taxed once by the transfer
+// fees of the token, by the buy fee as it leaves the previous pair
+func swapExactIn(pool Pool, inputAmount *TokenAmount, fromPool bool) (*TokenAmount, Pool, error) {
+	pair, ok := pool.(*Pair)
+	if !ok {
+		return pool.SwapExactIn(inputAmount)
+	}
+	outputAmount, pair, err := pair.getOutputAmountFromPool(inputAmount, fromPool)
+	if err != nil {
+		return nil, nil, err
+	}
+	return outputAmount, pair, nil
+}
+
+// swapExactOut swaps through the pool for the output amount as a hop of a route, see swapExactIn
+func swapExactOut(pool Pool, outputAmount *TokenAmount, fromPool bool) (*TokenAmount, Pool, error) {
+	pair, ok := pool.(*Pair)
+	if !ok {
+		return pool.SwapExactOut(outputAmount)
+	}
+	inputAmount, pair, err := pair.getInputAmountFromPool(outputAmount, fromPool)
+	if err != nil {
+		return nil, nil, err
+	}
+	return inputAmount, pair, nil
+}
+
+// PairPools returns the pairs as pools
+func PairPools(pairs []*Pair) []Pool {
+	if pairs == nil {
+		return nil
+	}
+	pools := make([]Pool, len(pairs))
+	for i := range pairs {
+		pools[i] = pairs[i]
+	}
+	return pools
+}
+
+// poolPairs returns the pools as pairs, or nil if any of them is not a pair
+func poolPairs(pools []Pool) []*Pair {
+	pairs := make([]*Pair, len(pools))
+	for i := range pools {
+		pair, ok := pools[i].(*Pair)
+		if !ok {
+			return nil
+		}
+		pairs[i] = pair
+	}
+	return pairs
+}
+
+/**
+ * Given a list of pools of any kind, and a fixed amount in, returns the top `maxNumResults` trades that go from an
+ * input token amount to an output token, making at most `maxHops` hops, see BestTradeExactIn.
+ * @param pools the pools to consider in finding the best trade
+ * @param currencyAmountIn exact amount of input currency to spend
+ * @param currencyOut the desired currency out
+ */
+func BestPoolTradeExactIn(pools []Pool, currencyAmountIn *TokenAmount, currencyOut Asset,
+	options *BestTradeOptions) ([]*Trade, error) {
+	return bestTradeExactIn(pools, currencyAmountIn, currencyOut, options, nil, nil, nil)
+}
+
+/**
+ * Given a list of pools of any kind, and a fixed amount out, returns the top `maxNumResults` trades that go from an
+ * input token to an output token amount, making at most `maxHops` hops, see BestTradeExactOut.
+ * @param pools the pools to consider in finding the best trade
+ * @param currencyIn the currency to spend
+ * @param currencyAmountOut the exact amount of currency out
+ */
+func BestPoolTradeExactOut(pools []Pool, currencyIn Asset, currencyAmountOut *TokenAmount,
+	options *BestTradeOptions) ([]*Trade, error) {
+	return bestTradeExactOut(pools, currencyIn, currencyAmountOut, options, nil, nil, nil)
+}
@@ -0,0 +1,173 @@
+package entities
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+)
+
+// quotePool is a pool which is not a pair, quoting as the pair it wraps
+type quotePool struct {
+	*Pair
+}
+
+func (p *quotePool) SwapExactIn(inputAmount *TokenAmount) (*TokenAmount, Pool, error) {
+	outputAmount, pair, err := p.GetOutputAmount(inputAmount)
+	if err != nil {
+		return nil, nil, err
+	}
+	return outputAmount, &quotePool{pair}, nil
+}
+
+func (p *quotePool) SwapExactOut(outputAmount *TokenAmount) (*TokenAmount, Pool, error) {
+	inputAmount, pair, err := p.GetInputAmount(outputAmount)
+	if err != nil {
+		return nil, nil, err
+	}
+	return inputAmount, &quotePool{pair}, nil
+}
+
+// nolint funlen
+func TestPoolRoute(t *testing.T) {
+	token0, _ := NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000001"), 18, "t0", "")
+	token1, _ := NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000002"), 18, "t1", "")
+	token2, _ := NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000003"), 18, "t2", "")
+
+	tokenAmount_0_1000, _ := NewTokenAmount(token0, big.NewInt(1000))
+	tokenAmount_1_1000, _ := NewTokenAmount(token1, big.NewInt(1000))
+	tokenAmount_1_1200, _ := NewTokenAmount(token1, big.NewInt(1200))
+	tokenAmount_2_1000, _ := NewTokenAmount(token2, big.NewInt(1000))
+	tokenAmount_0_0, _ := NewTokenAmount(token0, big.NewInt(0))
+	tokenAmount_2_0, _ := NewTokenAmount(token2, big.NewInt(0))
+
+	pair_0_1, _ := NewPair(tokenAmount_0_1000, tokenAmount_1_1000)
+	pair_1_2, _ := NewPair(tokenAmount_1_1200, tokenAmount_2_1000)
+	empty_pair_0_2, _ := NewPair(tokenAmount_0_0, tokenAmount_2_0)
+	pool_1_2 := &quotePool{pair_1_2}
+
+	pairRoute, err := NewRoute([]*Pair{pair_0_1, pair_1_2}, token0, token2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	route, err := NewPoolRoute([]Pool{pair_0_1, pool_1_2}, token0, token2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the pairs of the route are only set if all the pools are pairs
+	{
+		if len(pairRoute.Pools) != 2 || len(pairRoute.Pairs) != 2 || pairRoute.Pairs[1] != pair_1_2 {
+			t.Error("wrong pairs for route")
+		}
+		if len(route.Pools) != 2 || route.Pools[1] != pool_1_2 || route.Pairs != nil {
+			t.Error("wrong pools for route")
+		}
+		if len(route.Path) != 3 || route.Path[1] != token1 || route.Path[2] != token2 {
+			t.Error("wrong path for route")
+		}
+	}
+	// the mid price is the product of the prices of the pools
+	{
+		expect := pairRoute.MidPrice.ToSignificant(6)
+		output := route.MidPrice.ToSignificant(6)
+		if expect != output {
+			t.Errorf("expect[%+v], but got[%+v]", expect, output)
+		}
+	}
+	// trades through the pools match the trades through the pairs
+	{
+		amount, _ := NewTokenAmount(token0, big.NewInt(100))
+		expectTrade, err := NewTrade(pairRoute, amount, constants.ExactInput)
+		if err != nil {
+			t.Fatal(err)
+		}
+		trade, err := NewTrade(route, amount, constants.ExactInput)
+		if err != nil {
+			t.Fatal(err)
+		}
+		{
+			expect := expectTrade.OutputAmount().Raw().String()
+			output := trade.OutputAmount().Raw().String()
+			if expect != output {
+				t.Errorf("expect[%+v], but got[%+v]", expect, output)
+			}
+		}
+		{
+			expect := expectTrade.PriceImpact.ToSignificant(6)
+			output := trade.PriceImpact.ToSignificant(6)
+			if expect != output {
+				t.Errorf("expect[%+v], but got[%+v]", expect, output)
+			}
+		}
+		{
+			expect := expectTrade.NextMidPrice.ToSignificant(6)
+			output := trade.NextMidPrice.ToSignificant(6)
+			if expect != output {
+				t.Errorf("expect[%+v], but got[%+v]", expect, output)
+			}
+		}
+	}
+	{
+		amount, _ := NewTokenAmount(token2, big.NewInt(10))
+		expectTrade, err := NewTrade(pairRoute, amount, constants.ExactOutput)
+		if err != nil {
+			t.Fatal(err)
+		}
+		trade, err := NewTrade(route, amount, constants.ExactOutput)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect := expectTrade.InputAmount().Raw().String()
+		output := trade.InputAmount().Raw().String()
+		if expect != output {
+			t.Errorf("expect[%+v], but got[%+v]", expect, output)
+		}
+	}
+	// the pair specific methods do not support other pools
+	{
+		if _, err := json.Marshal(route); err == nil {
+			t.Errorf("expect[%+v], but got[%+v]", ErrNotPair, err)
+		}
+		price := NewPrice(token0.Currency, token2.Currency, big.NewInt(2), big.NewInt(1))
+		if _, _, err := route.GetInputAmountForPrice(price); err != ErrNotPair {
+			t.Errorf("expect[%+v], but got[%+v]", ErrNotPair, err)
+		}
+	}
+
+	// finds the best trades through pools, skipping the empty ones
+	{
+		pools := []Pool{pair_0_1, pool_1_2, &quotePool{empty_pair_0_2}}
+		amount, _ := NewTokenAmount(token0, big.NewInt(100))
+		trades, err := BestPoolTradeExactIn(pools, amount, token2, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(trades) != 1 || len(trades[0].Route.Pools) != 2 || trades[0].Route.Pools[1] != pool_1_2 {
+			t.Fatalf("wrong trades %+v", trades)
+		}
+		expectTrades, err := BestTradeExactIn([]*Pair{pair_0_1, pair_1_2, empty_pair_0_2}, amount, token2, nil, nil, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect := expectTrades[0].OutputAmount().Raw().String()
+		output := trades[0].OutputAmount().Raw().String()
+		if expect != output {
+			t.Errorf("expect[%+v], but got[%+v]", expect, output)
+		}
+	}
+	{
+		pools := []Pool{pair_0_1, pool_1_2, &quotePool{empty_pair_0_2}}
+		amount, _ := NewTokenAmount(token2, big.NewInt(10))
+		trades, err := BestPoolTradeExactOut(pools, token0, amount, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(trades) != 1 || len(trades[0].Route.Pools) != 2 || trades[0].Route.Pools[0] != pair_0_1 {
+			t.Fatalf("wrong trades %+v", trades)
+		}
+	}
+}
@@ -17,17 +17,17 @@ type Price struct {
 }
 
 func NewPriceFromRoute(route *Route) (*Price, error) {
-	length := len(route.Pairs)
-	// NOTE: check route Pairs len?
+	length := len(route.Pools)
+	if length == 0 {
+		return nil, ErrInvalidPairs
+	}
 	prices := make([]*Price, length)
-	for i := range route.Pairs {
-		if route.Path[i].Equals(route.Pairs[i].Token0()) {
-			prices[i] = NewPrice(route.Pairs[i].Reserve0().Currency, route.Pairs[i].Reserve1().Currency,
-				route.Pairs[i].Reserve0().Raw(), route.Pairs[i].Reserve1().Raw())
-		} else {
-			prices[i] = NewPrice(route.Pairs[i].Reserve1().Currency, route.Pairs[i].Reserve0().Currency,
-				route.Pairs[i].Reserve1().Raw(), route.Pairs[i].Reserve0().Raw())
+	for i, pool := range route.Pools {
+		price, err := pool.PriceOf(route.Path[i])
+		if err != nil {
+			return nil, err
 		}
+		prices[i] = price
 	}
 
 	price := prices[0]
@@ -38,7 +38,8 @@ func NewPriceFromRoute(route *Route) (*Price, error) {
 			return nil, err
 		}
 	}
-	return price, nil
+	// express the price in terms of the route input and output, which may be ETHER
+	return NewPrice(route.Input.Currency, route.Output.Currency, price.Denominator, price.Numerator), nil
 }
 
 // denominator and numerator _must_ be raw, i.e. in the native representation
@@ -79,7 +80,7 @@ func (p *Price) Quote(currencyAmount *CurrencyAmount) (*CurrencyAmount, error) {
 		return nil, ErrInvalidCurrency
 	}
 
-	return NewEther(p.Fraction.Multiply(NewFraction(currencyAmount.Raw(), nil)).Quotient())
+	return NewCurrencyAmount(p.QuoteCurrency, p.Fraction.Multiply(NewFraction(currencyAmount.Raw(), nil)).Quotient())
 }
 
 func (p *Price) ToSignificant(significantDigits uint, opt ...number.Option) string {
@@ -0,0 +1,204 @@
+package entities
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+)
+
+var (
+	// ErrInvalidPrice the target price can not be reached
+	ErrInvalidPrice = fmt.Errorf("invalid target price")
+)
+
+// swapOutput returns the raw output amount of swapping the raw input amount, same as Pair.GetOutputAmount
+func swapOutput(protocol *Protocol, inputReserve, outputReserve, inputAmount *big.Int) *big.Int {
+	inputAmountWithFee := big.NewInt(0).Mul(inputAmount, protocol.FeeNumerator)
+	numerator := big.NewInt(0).Mul(inputAmountWithFee, outputReserve)
+	denominator := big.NewInt(0).Mul(inputReserve, protocol.FeeDenominator)
+	denominator.Add(denominator, inputAmountWithFee)
+	return numerator.Div(numerator, denominator)
+}
+
+// minimalInputAmount returns the minimal amount for which reached is true, reached must be monotone in the amount
+func minimalInputAmount(reached func(amount *big.Int) bool) (*big.Int, error) {
+	if reached(constants.Zero) {
+		return big.NewInt(0), nil
+	}
+
+	lo, hi := big.NewInt(0), big.NewInt(1)
+	for !reached(hi) {
+		lo.Set(hi)
+		hi.Lsh(hi, 1)
+		if hi.Cmp(constants.SolidityTypeMaxima[constants.Uint256]) > 0 {
+			return nil, ErrInvalidPrice
+		}
+	}
+	// reached(lo) is false and reached(hi) is true
+	for big.NewInt(0).Sub(hi, lo).Cmp(constants.One) > 0 {
+		mid := big.NewInt(0).Add(lo, hi)
+		mid.Rsh(mid, 1)
+		if reached(mid) {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return hi, nil
+}
+
+// GetInputAmountForPrice returns the minimal amount of the token to sell into the pair so that the mid price of the
+// pair reaches the target price, and the Pair after the swap.
+// The token sold is the base currency of the price if the target price is lower than the current price, and the
+// quote currency otherwise. The swap uses the same fees and rounding as GetOutputAmount.
+// @param price the target price, whose base and quote currencies are the currencies of the pair tokens
+func (p *Pair) GetInputAmountForPrice(price *Price) (*TokenAmount, *Pair, error) {
+	var target *Fraction
+	switch {
+	case price.BaseCurrency.Equals(p.Token0().Currency) && price.QuoteCurrency.Equals(p.Token1().Currency):
+		target = price.Raw()
+	case price.BaseCurrency.Equals(p.Token1().Currency) && price.QuoteCurrency.Equals(p.Token0().Currency):
+		target = price.Raw().Invert()
+	default:
+		return nil, nil, ErrInvalidCurrency
+	}
+	if target.Numerator.Sign() <= 0 || target.Denominator.Sign() <= 0 {
+		return nil, nil, ErrInvalidPrice
+	}
+	if p.Reserve0().Raw().Sign() == 0 || p.Reserve1().Raw().Sign() == 0 {
+		return nil, nil, ErrInsufficientReserves
+	}
+
+	// selling token0 lowers the token0 price, selling token1 raises it
+	inputReserve, outputReserve := p.Reserve0(), p.Reserve1()
+	if p.Token0Price().Raw().LessThan(target) {
+		inputReserve, outputReserve = outputReserve, inputReserve
+		target = target.Invert()
+	}
+
+	// the pair receives the amount taxed by the sell fee of fee on transfer tokens
+	pairInputOf := func(amount *big.Int) *big.Int {
+		return afterTransferFee(amount, inputReserve.Token.SellFee)
+	}
+	amount, err := minimalInputAmount(func(amount *big.Int) bool {
+		pairInput := pairInputOf(amount)
+		output := swapOutput(p.Protocol, inputReserve.Raw(), outputReserve.Raw(), pairInput)
+		// (outputReserve - output) / (inputReserve + pairInput) <= target
+		left := big.NewInt(0).Sub(outputReserve.Raw(), output)
+		left.Mul(left, target.Denominator)
+		right := big.NewInt(0).Add(inputReserve.Raw(), pairInput)
+		right.Mul(right, target.Numerator)
+		return left.Cmp(right) <= 0
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	inputAmount, err := NewTokenAmount(inputReserve.Token, amount)
+	if err != nil {
+		return nil, nil, err
+	}
+	pairInput, err := NewTokenAmount(inputReserve.Token, pairInputOf(amount))
+	if err != nil {
+		return nil, nil, err
+	}
+	pairOutput, err := NewTokenAmount(outputReserve.Token,
+		swapOutput(p.Protocol, inputReserve.Raw(), outputReserve.Raw(), pairInput.Raw()))
+	if err != nil {
+		return nil, nil, err
+	}
+	pair, err := p.nextPair(pairInput, pairOutput)
+	if err != nil {
+		return nil, nil, err
+	}
+	return inputAmount, pair, nil
+}
+
+// GetInputAmountForPrice returns the minimal amount of the route input to sell through the route so that the mid
+// price of the route reaches the target price, and the Route through the pairs after the swap.
+// Selling the input can only lower the mid price, so the target price must not be higher than the current one.
+// @param price the target price, whose base and quote currencies are the currencies of the route input and output
+func (r *Route) GetInputAmountForPrice(price *Price) (*TokenAmount, *Route, error) {
+	if r.Pairs == nil {
+		return nil, nil, ErrNotPair
+	}
+	var target *Fraction
+	switch {
+	case price.BaseCurrency.Equals(r.Input.Currency) && price.QuoteCurrency.Equals(r.Output.Currency):
+		target = price.Raw()
+	case price.BaseCurrency.Equals(r.Output.Currency) && price.QuoteCurrency.Equals(r.Input.Currency):
+		target = price.Raw().Invert()
+	default:
+		return nil, nil, ErrInvalidCurrency
+	}
+	if target.Numerator.Sign() <= 0 || target.Denominator.Sign() <= 0 || r.MidPrice.Raw().LessThan(target) {
+		return nil, nil, ErrInvalidPrice
+	}
+
+	reserves := make([][2]*TokenAmount, len(r.Pairs))
+	for i, pair := range r.Pairs {
+		if pair.Reserve0().Raw().Sign() == 0 || pair.Reserve1().Raw().Sign() == 0 {
+			return nil, nil, ErrInsufficientReserves
+		}
+		reserves[i] = [2]*TokenAmount{pair.Reserve0(), pair.Reserve1()}
+		if r.Path[i].Equals(pair.Token1()) {
+			reserves[i] = [2]*TokenAmount{pair.Reserve1(), pair.Reserve0()}
+		}
+	}
+	// amounts returns the raw amounts received and sent by the pairs for the raw input amount, taxed by the transfer
+	// fees of fee on transfer tokens, the transfers between the pairs are only taxed by the buy fee
+	amounts := func(amount *big.Int) (pairInputs, pairOutputs []*big.Int) {
+		pairInputs, pairOutputs = make([]*big.Int, len(r.Pairs)), make([]*big.Int, len(r.Pairs))
+		for i, pair := range r.Pairs {
+			inputReserve, outputReserve := reserves[i][0], reserves[i][1]
+			pairInputs[i] = amount
+			if i == 0 {
+				pairInputs[i] = afterTransferFee(amount, inputReserve.Token.SellFee)
+			}
+			pairOutputs[i] = swapOutput(pair.Protocol, inputReserve.Raw(), outputReserve.Raw(), pairInputs[i])
+			amount = afterTransferFee(pairOutputs[i], outputReserve.Token.BuyFee)
+		}
+		return pairInputs, pairOutputs
+	}
+
+	amount, err := minimalInputAmount(func(amount *big.Int) bool {
+		pairInputs, pairOutputs := amounts(amount)
+		// the product of (outputReserve - pairOutput) / (inputReserve + pairInput) <= target
+		left, right := big.NewInt(0).Set(target.Denominator), big.NewInt(0).Set(target.Numerator)
+		for i := range r.Pairs {
+			left.Mul(left, big.NewInt(0).Sub(reserves[i][1].Raw(), pairOutputs[i]))
+			right.Mul(right, big.NewInt(0).Add(reserves[i][0].Raw(), pairInputs[i]))
+		}
+		return left.Cmp(right) <= 0
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pairInputs, pairOutputs := amounts(amount)
+	nextPairs := make([]*Pair, len(r.Pairs))
+	for i, pair := range r.Pairs {
+		pairInput, err := NewTokenAmount(r.Path[i], pairInputs[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		pairOutput, err := NewTokenAmount(r.Path[i+1], pairOutputs[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		nextPairs[i], err = pair.nextPair(pairInput, pairOutput)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	nextRoute, err := NewRoute(nextPairs, r.Input, r.Output)
+	if err != nil {
+		return nil, nil, err
+	}
+	inputAmount, err := NewTokenAmount(r.Input, amount)
+	if err != nil {
+		return nil, nil, err
+	}
+	return inputAmount, nextRoute, nil
+}
@@ -0,0 +1,226 @@
+package entities
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+)
+
+// nolint funlen
+func TestPair_GetInputAmountForPrice(t *testing.T) {
+	token0, _ := NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000001"), 18, "t0", "")
+	token1, _ := NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000002"), 18, "t1", "")
+	token2, _ := NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000003"), 18, "t2", "")
+	tokenAmount_0_1000, _ := NewTokenAmount(token0, big.NewInt(1000))
+	tokenAmount_1_2000, _ := NewTokenAmount(token1, big.NewInt(2000))
+	pair_0_1, _ := NewPair(tokenAmount_0_1000, tokenAmount_1_2000)
+
+	// the previous amount must not reach the target
+	assertMinimal := func(pair *Pair, inputAmount *TokenAmount, target *Price) {
+		prev, _ := NewTokenAmount(inputAmount.Token, big.NewInt(0).Sub(inputAmount.Raw(), constants.One))
+		_, prevPair, err := pair.GetOutputAmount(prev)
+		if err != nil {
+			t.Fatal(err)
+		}
+		price := prevPair.Token0Price().Raw()
+		if target.BaseCurrency.Equals(token1.Currency) {
+			price = prevPair.Token1Price().Raw()
+		}
+		if inputAmount.Token.Equals(pair.Token0()) == target.BaseCurrency.Equals(token0.Currency) {
+			if !target.Raw().LessThan(price) {
+				t.Errorf("expect minimal input amount, but %s reaches the target", prev.Raw())
+			}
+		} else if !price.LessThan(target.Raw()) {
+			t.Errorf("expect minimal input amount, but %s reaches the target", prev.Raw())
+		}
+	}
+
+	// sells token0 to lower the token0 price
+	{
+		target := NewPrice(token0.Currency, token1.Currency, big.NewInt(1), big.NewInt(1))
+		inputAmount, nextPair, err := pair_0_1.GetInputAmountForPrice(target)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !inputAmount.Token.Equals(token0) {
+			t.Errorf("expect[%+v], but got[%+v]", token0, inputAmount.Token)
+		}
+		expect := "415"
+		if output := inputAmount.Raw().String(); output != expect {
+			t.Errorf("expect[%+v], but got[%+v]", expect, output)
+		}
+		if target.Raw().LessThan(nextPair.Token0Price().Raw()) {
+			t.Errorf("expect price %s not above target, but got %s",
+				target.ToSignificant(6), nextPair.Token0Price().ToSignificant(6))
+		}
+		_, expectPair, err := pair_0_1.GetOutputAmount(inputAmount)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if nextPair.Reserve0().Raw().Cmp(expectPair.Reserve0().Raw()) != 0 ||
+			nextPair.Reserve1().Raw().Cmp(expectPair.Reserve1().Raw()) != 0 {
+			t.Errorf("expect[%+v], but got[%+v]", expectPair.TokenAmounts, nextPair.TokenAmounts)
+		}
+		assertMinimal(pair_0_1, inputAmount, target)
+	}
+
+	// sells token1 to raise the token0 price, with the price given as the token1 price
+	{
+		target := NewPrice(token1.Currency, token0.Currency, big.NewInt(3), big.NewInt(1))
+		inputAmount, nextPair, err := pair_0_1.GetInputAmountForPrice(target)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !inputAmount.Token.Equals(token1) {
+			t.Errorf("expect[%+v], but got[%+v]", token1, inputAmount.Token)
+		}
+		if nextPair.Token1Price().Raw().LessThan(target.Raw()) {
+			t.Errorf("expect price %s not below target, but got %s",
+				target.ToSignificant(6), nextPair.Token1Price().ToSignificant(6))
+		}
+		assertMinimal(pair_0_1, inputAmount, target)
+	}
+
+	// zero when the price is already reached
+	{
+		target := NewPrice(token0.Currency, token1.Currency, big.NewInt(1), big.NewInt(2))
+		inputAmount, nextPair, err := pair_0_1.GetInputAmountForPrice(target)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if inputAmount.Raw().Sign() != 0 {
+			t.Errorf("expect[0], but got[%+v]", inputAmount.Raw())
+		}
+		if !nextPair.Token0Price().Raw().EqualTo(pair_0_1.Token0Price().Raw()) {
+			t.Errorf("expect[%+v], but got[%+v]", pair_0_1.Token0Price(), nextPair.Token0Price())
+		}
+	}
+
+	// throws for currencies not in the pair
+	{
+		target := NewPrice(token0.Currency, token2.Currency, big.NewInt(1), big.NewInt(1))
+		_, _, output := pair_0_1.GetInputAmountForPrice(target)
+		if output != ErrInvalidCurrency {
+			t.Errorf("expect[%+v], but got[%+v]", ErrInvalidCurrency, output)
+		}
+	}
+
+	// throws for a zero price
+	{
+		target := NewPrice(token0.Currency, token1.Currency, big.NewInt(1), big.NewInt(0))
+		_, _, output := pair_0_1.GetInputAmountForPrice(target)
+		if output != ErrInvalidPrice {
+			t.Errorf("expect[%+v], but got[%+v]", ErrInvalidPrice, output)
+		}
+	}
+}
+
+// nolint funlen
+func TestRoute_GetInputAmountForPrice(t *testing.T) {
+	token0, _ := NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000001"), 18, "t0", "")
+	token1, _ := NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000002"), 18, "t1", "")
+	token2, _ := NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000003"), 18, "t2", "")
+	tokenAmount_0_1000, _ := NewTokenAmount(token0, big.NewInt(1000))
+	tokenAmount_1_1000, _ := NewTokenAmount(token1, big.NewInt(1000))
+	tokenAmount_1_2000, _ := NewTokenAmount(token1, big.NewInt(2000))
+	tokenAmount_2_1000, _ := NewTokenAmount(token2, big.NewInt(1000))
+	pair_0_1, _ := NewPair(tokenAmount_0_1000, tokenAmount_1_2000)
+	pair_1_2, _ := NewPair(tokenAmount_1_1000, tokenAmount_2_1000)
+
+	route, err := NewRoute([]*Pair{pair_0_1, pair_1_2}, token0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a single pair route matches the pair
+	{
+		single, err := NewRoute([]*Pair{pair_0_1}, token0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		target := NewPrice(token0.Currency, token1.Currency, big.NewInt(1), big.NewInt(1))
+		expect, _, err := pair_0_1.GetInputAmountForPrice(target)
+		if err != nil {
+			t.Fatal(err)
+		}
+		output, _, err := single.GetInputAmountForPrice(target)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expect.Raw().Cmp(output.Raw()) != 0 {
+			t.Errorf("expect[%+v], but got[%+v]", expect.Raw(), output.Raw())
+		}
+	}
+
+	// moves the mid price of a multi-hop route
+	{
+		target := NewPrice(token0.Currency, token2.Currency, big.NewInt(1), big.NewInt(1))
+		inputAmount, nextRoute, err := route.GetInputAmountForPrice(target)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !inputAmount.Token.Equals(token0) {
+			t.Errorf("expect[%+v], but got[%+v]", token0, inputAmount.Token)
+		}
+		if target.Raw().LessThan(nextRoute.MidPrice.Raw()) {
+			t.Errorf("expect price %s not above target, but got %s",
+				target.ToSignificant(6), nextRoute.MidPrice.ToSignificant(6))
+		}
+		trade, err := ExactIn(route, inputAmount)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !trade.NextMidPrice.Raw().EqualTo(nextRoute.MidPrice.Raw()) {
+			t.Errorf("expect[%+v], but got[%+v]", trade.NextMidPrice.ToSignificant(6), nextRoute.MidPrice.ToSignificant(6))
+		}
+
+		prev, _ := NewTokenAmount(token0, big.NewInt(0).Sub(inputAmount.Raw(), constants.One))
+		trade, err = ExactIn(route, prev)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !target.Raw().LessThan(trade.NextMidPrice.Raw()) {
+			t.Errorf("expect minimal input amount, but %s reaches the target", prev.Raw())
+		}
+	}
+
+	// the transfer between the pairs of a taxed token is only taxed by the buy fee, as the trades do
+	{
+		taxed, _ := NewToken(constants.Mainnet, token1.Address, 18, "t1", "")
+		taxed, err := taxed.WithTransferFees(NewPercent(big.NewInt(10), big.NewInt(100)),
+			NewPercent(big.NewInt(10), big.NewInt(100)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		taxed_0_1, _ := NewPair(tokenAmount_0_1000, mustTokenAmount(t, taxed, 2000))
+		taxed_1_2, _ := NewPair(mustTokenAmount(t, taxed, 1000), tokenAmount_2_1000)
+		taxedRoute, err := NewRoute([]*Pair{taxed_0_1, taxed_1_2}, token0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		target := NewPrice(token0.Currency, token2.Currency, big.NewInt(1), big.NewInt(1))
+		inputAmount, nextRoute, err := taxedRoute.GetInputAmountForPrice(target)
+		if err != nil {
+			t.Fatal(err)
+		}
+		trade, err := ExactIn(taxedRoute, inputAmount)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !trade.NextMidPrice.Raw().EqualTo(nextRoute.MidPrice.Raw()) {
+			t.Errorf("expect[%+v], but got[%+v]", trade.NextMidPrice.ToSignificant(6), nextRoute.MidPrice.ToSignificant(6))
+		}
+	}
+
+	// throws if the target is above the mid price
+	{
+		target := NewPrice(token0.Currency, token2.Currency, big.NewInt(1), big.NewInt(3))
+		_, _, output := route.GetInputAmountForPrice(target)
+		if output != ErrInvalidPrice {
+			t.Errorf("expect[%+v], but got[%+v]", ErrInvalidPrice, output)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package entities
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+)
+
+var (
+	// UniswapV2 the Uniswap V2 protocol, the default protocol of pairs
+	UniswapV2 = NewProtocol("Uniswap V2", constants.FactoryAddress, constants.InitCodeHash,
+		constants.B997, constants.B1000)
+	// PancakeSwapV1 the PancakeSwap V1 protocol on BSC, 0.2% fee
+	PancakeSwapV1 = NewProtocol("PancakeSwap V1",
+		common.HexToAddress("0xBCfCcbde45cE874adCB698cC183deBcF17952812"),
+		common.FromHex("0xd0d4c4cd0848c93cb4fd1f498d7013ee6bfb25783ea21593d5834f5d250ece66"),
+		big.NewInt(998), constants.B1000).
+		WithLiquidityToken("Cake-LP", "Pancake LPs")
+	// PancakeSwapV2 the PancakeSwap V2 protocol on BSC, 0.25% fee
+	PancakeSwapV2 = NewProtocol("PancakeSwap V2",
+		common.HexToAddress("0xcA143Ce32Fe78f1f7019d7d551a6402fC5350c73"),
+		common.FromHex("0x00fb7f630766e6a796048ea87d01acd3068e8ff67d078148a3fa3f4a84f69bd5"),
+		big.NewInt(9975), big.NewInt(10000)).
+		WithLiquidityToken("Cake-LP", "Pancake LPs")
+	// QuickSwap the QuickSwap protocol on Polygon, 0.3% fee
+	QuickSwap = NewProtocol("QuickSwap",
+		common.HexToAddress("0x5757371414417b8C6CAad45bAeF941aBc7d3Ab32"),
+		constants.InitCodeHash,
+		constants.B997, constants.B1000)
+
+	// the Uniswap V2 deployments on the other chains, which share the init code hash and fee of UniswapV2
+	UniswapV2Sepolia = NewProtocol("Uniswap V2",
+		common.HexToAddress("0xF62c03E08ada871A0bEb309762E260a7a6a880E6"),
+		constants.InitCodeHash, constants.B997, constants.B1000)
+	UniswapV2Optimism = NewProtocol("Uniswap V2",
+		common.HexToAddress("0x0c3c1c532F1e39EdF36BE9Fe0bE1410313E074Bf"),
+		constants.InitCodeHash, constants.B997, constants.B1000)
+	UniswapV2Arbitrum = NewProtocol("Uniswap V2",
+		common.HexToAddress("0xf1D7CC64Fb4452F05c498126312eBE29f30Fbcf9"),
+		constants.InitCodeHash, constants.B997, constants.B1000)
+	UniswapV2Polygon = NewProtocol("Uniswap V2",
+		common.HexToAddress("0x9e5A52f57b3038F1B8EeE45F28b3C1967e22799C"),
+		constants.InitCodeHash, constants.B997, constants.B1000)
+	// UniswapV2BSC the Uniswap V2 deployment on BSC, at the same address as on Base
+	UniswapV2BSC = NewProtocol("Uniswap V2",
+		common.HexToAddress("0x8909Dc15e40173Ff4699343b6eB8132c65e18eC6"),
+		constants.InitCodeHash, constants.B997, constants.B1000)
+	UniswapV2Base = UniswapV2BSC
+
+	// knownProtocols the built-in protocols
+	knownProtocols = []*Protocol{UniswapV2, PancakeSwapV1, PancakeSwapV2, QuickSwap,
+		UniswapV2Sepolia, UniswapV2Optimism, UniswapV2Arbitrum, UniswapV2Polygon, UniswapV2BSC}
+)
+
+// Protocol describes a Uniswap V2 compatible AMM, i.e. Uniswap V2 itself or one of its forks.
+// It drives the pair address derivation and the swap fee math of the pairs which carry it.
+type Protocol struct {
+	Name           string
+	FactoryAddress common.Address
+	InitCodeHash   []byte
+	// the input amount is multiplied by FeeNumerator/FeeDenominator before swapping, e.g. 997/1000 is a 0.3% fee
+	FeeNumerator   *big.Int
+	FeeDenominator *big.Int
+	// the liquidity token metadata
+	LiquiditySymbol string
+	LiquidityName   string
+
+	addresses *PairAddressCache
+}
+
+// NewProtocol creates a Protocol whose liquidity token metadata is the same as Uniswap V2
+func NewProtocol(name string, factoryAddress common.Address, initCodeHash []byte, feeNumerator, feeDenominator *big.Int) *Protocol {
+	return &Protocol{
+		Name:            name,
+		FactoryAddress:  factoryAddress,
+		InitCodeHash:    initCodeHash,
+		FeeNumerator:    feeNumerator,
+		FeeDenominator:  feeDenominator,
+		LiquiditySymbol: constants.Univ2Symbol,
+		LiquidityName:   constants.Univ2Name,
+		addresses: &PairAddressCache{
+			lk:           new(sync.RWMutex),
+			address:      make(map[common.Address]map[common.Address]common.Address, 16),
+			factory:      factoryAddress,
+			initCodeHash: initCodeHash,
+		},
+	}
+}
+
+// WithLiquidityToken returns a copy of the protocol with the liquidity token metadata, the protocol itself is left
+// untouched
+func (p *Protocol) WithLiquidityToken(symbol, name string) *Protocol {
+	protocol := *p
+	protocol.LiquiditySymbol = symbol
+	protocol.LiquidityName = name
+	return &protocol
+}
+
+// GetPairAddress returns the contract address of the pair for tokenA and tokenB, in any order
+func (p *Protocol) GetPairAddress(tokenA, tokenB *Token) (common.Address, error) {
+	ok, err := tokenA.SortsBefore(tokenB)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if !ok {
+		tokenA, tokenB = tokenB, tokenA
+	}
+	return p.addresses.GetAddress(tokenA.Address, tokenB.Address), nil
+}
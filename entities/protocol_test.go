@@ -0,0 +1,128 @@
+package entities
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+)
+
+func TestProtocol_GetPairAddress(t *testing.T) {
+	const (
+		bsc     = constants.ChainID(56)
+		polygon = constants.ChainID(137)
+	)
+	WBNB, _ := NewToken(bsc, common.HexToAddress("0xbb4CdB9CBd36B01bD1cBaEBF2De08d9173bc095c"), 18, "WBNB", "Wrapped BNB")
+	BUSD, _ := NewToken(bsc, common.HexToAddress("0xe9e7CEA3DedcA5984780Bafc599bD69ADd087D56"), 18, "BUSD", "BUSD Token")
+	WMATIC, _ := NewToken(polygon, common.HexToAddress("0x0d500B1d8E8eF31E21C99d1Db9A6444d3ADf1270"), 18, "WMATIC", "Wrapped Matic")
+	USDC, _ := NewToken(polygon, common.HexToAddress("0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174"), 6, "USDC", "USD Coin")
+
+	tests := []struct {
+		name     string
+		protocol *Protocol
+		tokens   [2]*Token
+		want     string
+	}{
+		{"PancakeSwap V1 WBNB/BUSD", PancakeSwapV1, [2]*Token{WBNB, BUSD}, "0x1B96B92314C44b159149f7E0303511fB2Fc4774f"},
+		{"PancakeSwap V2 WBNB/BUSD", PancakeSwapV2, [2]*Token{BUSD, WBNB}, "0x58F876857a02D6762E0101bb5C46A8c1ED44Dc16"},
+		{"QuickSwap WMATIC/USDC", QuickSwap, [2]*Token{WMATIC, USDC}, "0x6e7a5FAFcec6BB1e78bAE2A1F0B612012BF14827"},
+	}
+	for _, tt := range tests {
+		got, err := tt.protocol.GetPairAddress(tt.tokens[0], tt.tokens[1])
+		want := tt.want
+		t.Run(tt.name, func(t *testing.T) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.String() != want {
+				t.Errorf("GetPairAddress() = %v, want %v", got, want)
+			}
+		})
+	}
+
+	// pairs carry the protocol
+	{
+		tokenAmountA, _ := NewTokenAmount(WBNB, constants.B100)
+		tokenAmountB, _ := NewTokenAmount(BUSD, constants.B100)
+		pair, err := NewPairWithProtocol(tokenAmountA, tokenAmountB, PancakeSwapV2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect := "0x58F876857a02D6762E0101bb5C46A8c1ED44Dc16"
+		if output := pair.GetAddress().String(); output != expect {
+			t.Errorf("expect[%+v], but got[%+v]", expect, output)
+		}
+		if output := pair.LiquidityToken.Address.String(); output != expect {
+			t.Errorf("expect[%+v], but got[%+v]", expect, output)
+		}
+		if pair.LiquidityToken.Symbol != "Cake-LP" || pair.LiquidityToken.Name != "Pancake LPs" {
+			t.Errorf("wrong liquidity token[%+v]", pair.LiquidityToken.Currency)
+		}
+	}
+}
+
+func TestProtocol_Fee(t *testing.T) {
+	token0, _ := NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000001"), 18, "t0", "")
+	token1, _ := NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000002"), 18, "t1", "")
+	reserve0, _ := NewTokenAmount(token0, big.NewInt(10000))
+	reserve1, _ := NewTokenAmount(token1, big.NewInt(10000))
+	inputAmount, _ := NewTokenAmount(token0, big.NewInt(1000))
+	outputAmount, _ := NewTokenAmount(token1, big.NewInt(5000))
+
+	tests := []struct {
+		name     string
+		protocol *Protocol
+		output   int64
+		input    int64
+	}{
+		{"Uniswap V2", UniswapV2, 906, 10031},
+		{"PancakeSwap V1", PancakeSwapV1, 907, 10021},
+		{"PancakeSwap V2", PancakeSwapV2, 907, 10026},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			pair, err := NewPairWithProtocol(reserve0, reserve1, tt.protocol)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			output, nextPair, err := pair.GetOutputAmount(inputAmount)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if output.Raw().Int64() != tt.output {
+				t.Errorf("GetOutputAmount() = %v, want %v", output.Raw(), tt.output)
+			}
+			if nextPair.Protocol != tt.protocol {
+				t.Errorf("GetOutputAmount() next pair protocol = %v, want %v", nextPair.Protocol.Name, tt.protocol.Name)
+			}
+
+			input, nextPair, err := pair.GetInputAmount(outputAmount)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if input.Raw().Int64() != tt.input {
+				t.Errorf("GetInputAmount() = %v, want %v", input.Raw(), tt.input)
+			}
+			if nextPair.Protocol != tt.protocol {
+				t.Errorf("GetInputAmount() next pair protocol = %v, want %v", nextPair.Protocol.Name, tt.protocol.Name)
+			}
+		})
+	}
+}
+
+func TestProtocol_WithLiquidityToken(t *testing.T) {
+	protocol := UniswapV2.WithLiquidityToken("LP", "LP Token")
+	if protocol == UniswapV2 {
+		t.Fatal("expect a copy of the protocol")
+	}
+	if protocol.LiquiditySymbol != "LP" || protocol.LiquidityName != "LP Token" {
+		t.Errorf("wrong liquidity token[%s %s]", protocol.LiquiditySymbol, protocol.LiquidityName)
+	}
+	if UniswapV2.LiquiditySymbol != constants.Univ2Symbol || UniswapV2.LiquidityName != constants.Univ2Name {
+		t.Errorf("expect UniswapV2 untouched, but got[%s %s]", UniswapV2.LiquiditySymbol, UniswapV2.LiquidityName)
+	}
+}
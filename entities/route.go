@@ -15,60 +15,120 @@ var (
 )
 
 type Route struct {
-	Pairs    []*Pair
-	Path     []*Token
-	Input    *Token
-	Output   *Token
+	// the pools the route goes through
+	Pools []Pool
+	// the pools of the route as pairs, nil if any of them is not a pair
+	Pairs []*Pair
+	// the tokens the route goes through, ETHER is wrapped to WETH
+	Path []*Token
+	// the tokens the input and output amounts are expressed in, whose Currency is ETHER if it was supplied
+	Input  *Token
+	Output *Token
+	// the mid price of the route in terms of Input and Output
 	MidPrice *Price
 }
 
-func NewRoute(pairs []*Pair, input, output *Token) (*Route, error) {
-	if len(pairs) == 0 {
+// NewRoute creates a route through the pairs from input to output, the output is the last token of the path if it is nil
+func NewRoute(pairs []*Pair, input, output Asset) (*Route, error) {
+	return NewPoolRoute(PairPools(pairs), input, output)
+}
+
+// NewPoolRoute creates a route through pools of any kind from input to output, the output is the last token of the
+// path if it is nil
+func NewPoolRoute(pools []Pool, input, output Asset) (*Route, error) {
+	if len(pools) == 0 {
 		return nil, ErrInvalidPairs
 	}
 
-	for i := range pairs {
-		if pairs[i].ChainID() != pairs[0].ChainID() {
+	chainID := pools[0].ChainID()
+	for i := range pools {
+		if pools[i].ChainID() != chainID {
 			return nil, ErrInvalidPairsChainIDs
 		}
 	}
 
-	if !pairs[0].InvolvesToken(input) {
+	if isNilAsset(input) {
+		return nil, ErrInvalidInput
+	}
+	if isNilAsset(output) {
+		output = nil
+	}
+	wrappedInput, err := input.Wrapped(chainID)
+	if err != nil {
+		return nil, err
+	}
+	if !pools[0].InvolvesToken(wrappedInput) {
 		return nil, ErrInvalidInput
 	}
-	if !(output == nil || pairs[len(pairs)-1].InvolvesToken(output)) {
-		return nil, ErrInvalidOutput
+	var wrappedOutput *Token
+	if output != nil {
+		wrappedOutput, err = output.Wrapped(chainID)
+		if err != nil {
+			return nil, err
+		}
+		if !pools[len(pools)-1].InvolvesToken(wrappedOutput) {
+			return nil, ErrInvalidOutput
+		}
 	}
 
-	path := make([]*Token, len(pairs)+1)
-	path[0] = input
-	for i := range pairs {
+	path := make([]*Token, len(pools)+1)
+	path[0] = wrappedInput
+	for i := range pools {
 		currentInput := path[i]
-		if !(currentInput.Equals(pairs[i].Token0()) || currentInput.Equals(pairs[i].Token1())) {
+		if !(currentInput.Equals(pools[i].Token0()) || currentInput.Equals(pools[i].Token1())) {
 			return nil, ErrInvalidPath
 		}
-		currentOutput := pairs[i].Token0()
-		if currentInput.Equals(pairs[i].Token0()) {
-			currentOutput = pairs[i].Token1()
+		currentOutput := pools[i].Token0()
+		if currentInput.Equals(pools[i].Token0()) {
+			currentOutput = pools[i].Token1()
 		}
 		path[i+1] = currentOutput
 	}
 
-	if output == nil {
-		output = path[len(pairs)]
-	}
-
 	route := &Route{
-		Pairs:  pairs,
+		Pools:  pools,
+		Pairs:  poolPairs(pools),
 		Path:   path,
-		Input:  input,
-		Output: output,
+		Output: path[len(pools)],
+	}
+	route.Input, err = input.Unwrapped(chainID)
+	if err != nil {
+		return nil, err
+	}
+	if output != nil {
+		route.Output, err = output.Unwrapped(chainID)
+		if err != nil {
+			return nil, err
+		}
 	}
-	var err error
 	route.MidPrice, err = NewPriceFromRoute(route)
 	return route, err
 }
 
+// isNilAsset returns true if the asset is nil, including a nil *Token or *Currency held by the interface
+func isNilAsset(a Asset) bool {
+	switch v := a.(type) {
+	case nil:
+		return true
+	case *Token:
+		return v == nil
+	case *Currency:
+		return v == nil
+	}
+	return false
+}
+
 func (r *Route) ChainID() constants.ChainID {
-	return r.Pairs[0].ChainID()
+	return r.Pools[0].ChainID()
+}
+
+// FeeOnTransfer returns true if any token of the route taxes transfers, the trades through the route must use the
+// SupportingFeeOnTransferTokens methods of the router
+func (r *Route) FeeOnTransfer() bool {
+	for _, pool := range r.Pools {
+		if pool.Token0().HasTransferFees() || pool.Token1().HasTransferFees() {
+			return true
+		}
+	}
+	return false
 }
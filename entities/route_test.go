@@ -127,4 +127,92 @@ func TestRoute(t *testing.T) {
 			t.Error("wrong output for route")
 		}
 	}
+
+	// treats a nil *Token output as no output
+	{
+		var output *Token
+		route, err := NewRoute([]*Pair{pair01}, token0, output)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if route.Output != token1 {
+			t.Error("wrong output for route")
+		}
+	}
+
+	// rejects a nil *Token input
+	{
+		var input *Token
+		_, err := NewRoute([]*Pair{pair01}, input, token1)
+		if err != ErrInvalidInput {
+			t.Errorf("expect[%+v], but got[%+v]", ErrInvalidInput, err)
+		}
+	}
+}
+
+func TestRouteEther(t *testing.T) {
+	token0, _ := NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000001"), 18, "t0", "t0")
+	weth := WETH[constants.Mainnet]
+	tokenAmount0, _ := NewTokenAmount(token0, constants.B100)
+	tokenAmountWeth, _ := NewTokenAmount(weth, big.NewInt(200))
+	pair0Weth, _ := NewPair(tokenAmount0, tokenAmountWeth)
+
+	// supports ether input
+	{
+		route, err := NewRoute([]*Pair{pair0Weth}, ETHER, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if route.Path[0] != weth || route.Path[1] != token0 {
+			t.Error("wrong path for route")
+		}
+		if route.Input.Currency != ETHER || !route.Input.Equals(weth) {
+			t.Error("wrong input for route")
+		}
+		if route.Output != token0 {
+			t.Error("wrong output for route")
+		}
+		if route.MidPrice.BaseCurrency != ETHER || route.MidPrice.QuoteCurrency != token0.Currency {
+			t.Error("wrong mid price currencies for route")
+		}
+
+		etherAmount, _ := NewEther(big.NewInt(10))
+		quote, err := route.MidPrice.Quote(etherAmount)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if quote.Currency != token0.Currency || quote.Raw().Cmp(big.NewInt(5)) != 0 {
+			t.Errorf("wrong quote[%+v]", quote)
+		}
+	}
+
+	// supports ether output
+	{
+		route, err := NewRoute([]*Pair{pair0Weth}, token0, ETHER)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if route.Path[0] != token0 || route.Path[1] != weth {
+			t.Error("wrong path for route")
+		}
+		if route.Output.Currency != ETHER || !route.Output.Equals(weth) {
+			t.Error("wrong output for route")
+		}
+		if route.MidPrice.BaseCurrency != token0.Currency || route.MidPrice.QuoteCurrency != ETHER {
+			t.Error("wrong mid price currencies for route")
+		}
+	}
+
+	// ether is not available on chains without WETH
+	{
+		token, _ := NewToken(constants.ChainID(100), common.HexToAddress("0x0000000000000000000000000000000000000001"), 18, "t0", "t0")
+		other, _ := NewToken(constants.ChainID(100), common.HexToAddress("0x0000000000000000000000000000000000000002"), 18, "t1", "t1")
+		tokenAmount, _ := NewTokenAmount(token, constants.B100)
+		otherAmount, _ := NewTokenAmount(other, constants.B100)
+		pair, _ := NewPair(tokenAmount, otherAmount)
+		_, output := NewRoute([]*Pair{pair}, ETHER, nil)
+		if output != ErrNoWETH {
+			t.Errorf("expect[%+v], but got[%+v]", ErrNoWETH, output)
+		}
+	}
 }
@@ -2,6 +2,7 @@ package entities
 
 import (
 	"fmt"
+	"math/big"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -14,35 +15,72 @@ var (
 	ErrDiffChainID = fmt.Errorf("diff chain id")
 	ErrDiffToken   = fmt.Errorf("diff token")
 	ErrSameAddrss  = fmt.Errorf("same address")
+	// ErrInvalidTransferFee transfer fees must be in [0, 1)
+	ErrInvalidTransferFee = fmt.Errorf("invalid transfer fee")
 
 	_WETHCurrency, _ = newCurrency(constants.Decimals18, "WETH", "Wrapped Ether")
 
+	// WETH the wrapped ether of the built-in chains whose native currency is ETHER, the wrapped native tokens of all
+	// the chains, including the chains registered at runtime, are looked up through GetChain
 	WETH = map[constants.ChainID]*Token{
 		constants.Mainnet: {
 			Currency: _WETHCurrency,
 			ChainID:  constants.Mainnet,
-			Address:  utils.ValidateAndParseAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"),
+			Address:  utils.MustParseAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"),
 		},
 		constants.Ropsten: {
 			Currency: _WETHCurrency,
 			ChainID:  constants.Ropsten,
-			Address:  utils.ValidateAndParseAddress("0xc778417E063141139Fce010982780140Aa0cD5Ab"),
+			Address:  utils.MustParseAddress("0xc778417E063141139Fce010982780140Aa0cD5Ab"),
 		},
 		constants.Rinkeby: {
 			Currency: _WETHCurrency,
 			ChainID:  constants.Rinkeby,
-			Address:  utils.ValidateAndParseAddress("0xc778417E063141139Fce010982780140Aa0cD5Ab"),
+			Address:  utils.MustParseAddress("0xc778417E063141139Fce010982780140Aa0cD5Ab"),
 		},
 		constants.Goerli: {
 			Currency: _WETHCurrency,
 			ChainID:  constants.Goerli,
-			Address:  utils.ValidateAndParseAddress("0xB4FBF271143F4FBf7B91A5ded31805e42b2208d6"),
+			Address:  utils.MustParseAddress("0xB4FBF271143F4FBf7B91A5ded31805e42b2208d6"),
 		},
 		constants.Kovan: {
 			Currency: _WETHCurrency,
 			ChainID:  constants.Kovan,
-			Address:  utils.ValidateAndParseAddress("0xd0A1E359811322d97991E03f863a0C30C2cF029C"),
+			Address:  utils.MustParseAddress("0xd0A1E359811322d97991E03f863a0C30C2cF029C"),
 		},
+		constants.Sepolia: {
+			Currency: _WETHCurrency,
+			ChainID:  constants.Sepolia,
+			Address:  utils.MustParseAddress("0xfFf9976782d46CC05630D1f6eBAb18b2324d6B14"),
+		},
+		constants.Optimism: {
+			Currency: _WETHCurrency,
+			ChainID:  constants.Optimism,
+			Address:  utils.MustParseAddress("0x4200000000000000000000000000000000000006"),
+		},
+		constants.Arbitrum: {
+			Currency: _WETHCurrency,
+			ChainID:  constants.Arbitrum,
+			Address:  utils.MustParseAddress("0x82aF49447D8a07e3bd95BD0d56f35241523fBab1"),
+		},
+		constants.Base: {
+			Currency: _WETHCurrency,
+			ChainID:  constants.Base,
+			Address:  utils.MustParseAddress("0x4200000000000000000000000000000000000006"),
+		},
+	}
+
+	// WBNB the wrapped native token of BSC
+	WBNB = &Token{
+		Currency: &Currency{Decimals: constants.Decimals18, Symbol: "WBNB", Name: "Wrapped BNB"},
+		ChainID:  constants.BSC,
+		Address:  utils.MustParseAddress("0xbb4CdB9CBd36B01bD1cBaEBF2De08d9173bc095c"),
+	}
+	// WMATIC the wrapped native token of Polygon
+	WMATIC = &Token{
+		Currency: &Currency{Decimals: constants.Decimals18, Symbol: "WMATIC", Name: "Wrapped Matic"},
+		ChainID:  constants.Polygon,
+		Address:  utils.MustParseAddress("0x0d500B1d8E8eF31E21C99d1Db9A6444d3ADf1270"),
 	}
 )
 
@@ -54,6 +92,13 @@ type Token struct {
 
 	constants.ChainID
 	common.Address
+
+	// the fees taxed by fee on transfer tokens, of the amount transferred out of a pair, i.e. bought,
+	// and into a pair, i.e. sold, nil if not taxed. the transfer between the pairs of a multi hop trade is a single
+	// transfer, it is only taxed by the buy fee.
+	// the fees are in [0, 1), see WithTransferFees.
+	BuyFee  *Percent
+	SellFee *Percent
 }
 
 func NewToken(chainID constants.ChainID, address common.Address, decimals int, symbol, name string) (*Token, error) {
@@ -69,6 +114,17 @@ func NewToken(chainID constants.ChainID, address common.Address, decimals int, s
 	}, nil
 }
 
+// NewTokenFromHex creates a token whose hex address is parsed by utils.ParseChainAddress, so that malformed
+// addresses and addresses which do not match their checksum are rejected
+// @param mode the address parsing mode, utils.Lenient or utils.Strict
+func NewTokenFromHex(chainID constants.ChainID, address string, mode utils.AddressMode, decimals int, symbol, name string) (*Token, error) {
+	parsed, err := utils.ParseChainAddress(address, chainID, mode)
+	if err != nil {
+		return nil, err
+	}
+	return NewToken(chainID, parsed, decimals, symbol, name)
+}
+
 /**
  * Returns true if the two tokens are equivalent, i.e. have the same chainId and address.
  * @param other other token to compare
@@ -98,6 +154,70 @@ func (t *Token) SortsBefore(other *Token) (bool, error) {
 	return strings.ToLower(t.Address.String()) < strings.ToLower(other.Address.String()), nil
 }
 
+// WithTransferFees returns a copy of the token taxed by the given fees on transfer, nil if not taxed,
+// the token itself is left untouched since it may be shared, e.g. WETH or the tokens of pairs
+// @throws ErrInvalidTransferFee if a fee is not in [0, 1) or its denominator is not positive
+func (t *Token) WithTransferFees(buyFee, sellFee *Percent) (*Token, error) {
+	if !validTransferFee(buyFee) || !validTransferFee(sellFee) {
+		return nil, ErrInvalidTransferFee
+	}
+	token := *t
+	token.BuyFee = buyFee
+	token.SellFee = sellFee
+	return &token, nil
+}
+
+// validTransferFee returns true if the fee is nil or in [0, 1) with a positive denominator
+func validTransferFee(fee *Percent) bool {
+	return fee == nil || (fee.Denominator.Sign() > 0 && fee.Numerator.Sign() >= 0 &&
+		fee.Numerator.Cmp(fee.Denominator) < 0)
+}
+
+// HasTransferFees returns true if the token taxes transfers into or out of a pair
+func (t *Token) HasTransferFees() bool {
+	return (t.BuyFee != nil && t.BuyFee.Numerator.Sign() != 0) || (t.SellFee != nil && t.SellFee.Numerator.Sign() != 0)
+}
+
+// afterTransferFee returns the amount received for transferring the raw amount taxed by the fee
+func afterTransferFee(amount *big.Int, fee *Percent) *big.Int {
+	if fee == nil {
+		return amount
+	}
+	tax := big.NewInt(0).Mul(amount, fee.Numerator)
+	tax.Div(tax, fee.Denominator)
+	return tax.Sub(amount, tax)
+}
+
+// beforeTransferFee returns the minimal raw amount to transfer so that the amount is received after the fee
+func beforeTransferFee(amount *big.Int, fee *Percent) (*big.Int, error) {
+	if fee == nil || amount.Sign() == 0 {
+		return amount, nil
+	}
+	if !validTransferFee(fee) {
+		return nil, ErrInvalidTransferFee
+	}
+
+	// amount - floor(amount * fee) = ceil(amount * (1 - fee)), so the minimal x is floor((amount - 1) / (1 - fee)) + 1
+	x := big.NewInt(0).Sub(amount, constants.One)
+	x.Mul(x, fee.Denominator)
+	x.Div(x, big.NewInt(0).Sub(fee.Denominator, fee.Numerator))
+	return x.Add(x, constants.One), nil
+}
+
+// Wrapped returns the wrapped native token of the token chain, e.g. WETH, if the token's currency is native,
+// otherwise the token itself
+func (t *Token) Wrapped(constants.ChainID) (*Token, error) {
+	if t.Currency.IsNative() {
+		return t.Currency.Wrapped(t.ChainID)
+	}
+	return t, nil
+}
+
+// Unwrapped returns the token itself
+func (t *Token) Unwrapped(constants.ChainID) (*Token, error) {
+	return t, nil
+}
+
 // NewETHRToken creates a token that currency is ETH
 func NewETHRToken(chainID constants.ChainID, address common.Address) *Token {
 	return &Token{
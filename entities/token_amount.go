@@ -1,6 +1,10 @@
 package entities
 
-import "math/big"
+import (
+	"math/big"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+)
 
 type TokenAmount struct {
 	*CurrencyAmount
@@ -20,6 +24,31 @@ func NewTokenAmount(token *Token, amount *big.Int) (*TokenAmount, error) {
 	}, nil
 }
 
+// NewEtherAmount creates a TokenAmount of ETHER on the given chain, whose Token is at the WETH address
+// amount _must_ be raw, i.e. in wei
+func NewEtherAmount(chainID constants.ChainID, amount *big.Int) (*TokenAmount, error) {
+	token, err := ETHER.Unwrapped(chainID)
+	if err != nil {
+		return nil, err
+	}
+	return NewTokenAmount(token, amount)
+}
+
+// NewNativeAmount creates a TokenAmount of the native currency of the chain, e.g. BNB on BSC, whose Token is at the
+// wrapped native token address
+// amount _must_ be raw
+func NewNativeAmount(chainID constants.ChainID, amount *big.Int) (*TokenAmount, error) {
+	chain, err := GetChain(chainID)
+	if err != nil {
+		return nil, err
+	}
+	token, err := chain.NativeCurrency.Unwrapped(chainID)
+	if err != nil {
+		return nil, err
+	}
+	return NewTokenAmount(token, amount)
+}
+
 func (t *TokenAmount) Add(other *TokenAmount) (*TokenAmount, error) {
 	if !t.Token.Equals(other.Token) {
 		return nil, ErrDiffToken
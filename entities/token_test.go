@@ -6,6 +6,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/miraclesu/uniswap-sdk-go/constants"
+	"github.com/miraclesu/uniswap-sdk-go/utils"
 )
 
 // nolint funlen
@@ -99,3 +100,28 @@ func TestToken(t *testing.T) {
 		}
 	}
 }
+
+func TestNewTokenFromHex(t *testing.T) {
+	token, err := NewTokenFromHex(constants.Mainnet, "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", utils.Lenient, 18, "WETH", "Wrapped Ether")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !token.Equals(WETH[constants.Mainnet]) {
+		t.Errorf("expect[%+v], but got[%+v]", WETH[constants.Mainnet].Address.Hex(), token.Address.Hex())
+	}
+
+	var tests = []struct {
+		Address string
+		Mode    utils.AddressMode
+		Expect  error
+	}{
+		{"0x123", utils.Lenient, utils.ErrAddressLength},
+		{"0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", utils.Strict, utils.ErrAddressChecksum},
+		{"0xC02AAA39b223FE8D0A0e5C4F27eAD9083C756Cc2", utils.Lenient, utils.ErrAddressChecksum},
+	}
+	for i, test := range tests {
+		if _, output := NewTokenFromHex(constants.Mainnet, test.Address, test.Mode, 18, "", ""); output != test.Expect {
+			t.Errorf("test #%d: expect[%+v], but got[%+v]", i, test.Expect, output)
+		}
+	}
+}
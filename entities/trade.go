@@ -2,6 +2,7 @@ package entities
 
 import (
 	"fmt"
+	"math/big"
 
 	"github.com/miraclesu/uniswap-sdk-go/constants"
 )
@@ -10,11 +11,11 @@ var (
 	ErrInvalidSlippageTolerance = fmt.Errorf("invalid slippage tolerance")
 )
 
-// Trade Represents a trade executed against a list of pairs.
+// Trade Represents a trade executed against a list of pools.
 // Does not account for slippage, i.e. trades that front run this trade and move the price.
 type Trade struct {
 	/**
-	 * The route of the trade, i.e. which pairs the trade goes through.
+	 * The route of the trade, i.e. which pools the trade goes through.
 	 */
 	Route *Route
 	/**
@@ -41,6 +42,12 @@ type Trade struct {
 	 * The percent difference between the mid price before the trade and the trade execution price.
 	 */
 	PriceImpact *Percent
+	/**
+	 * The estimated gas used by the trade and its cost in the output currency for exact input trades, or in the
+	 * input currency for exact output trades, only set by the best trade search with gas options.
+	 */
+	EstimatedGas *big.Int
+	GasCost      *TokenAmount
 }
 
 func (t *Trade) InputAmount() *TokenAmount {
@@ -51,6 +58,12 @@ func (t *Trade) OutputAmount() *TokenAmount {
 	return t.outputAmount
 }
 
+// FeeOnTransfer returns true if the trade goes through fee on transfer tokens, so it must be executed with the
+// SupportingFeeOnTransferTokens methods of the router, which only support exact input trades
+func (t *Trade) FeeOnTransfer() bool {
+	return t.Route.FeeOnTransfer()
+}
+
 /**
  * Constructs an exact in trade with the given amount in and route
  * @param route route of the exact in trade
@@ -70,10 +83,12 @@ func ExactOut(route *Route, amountOut *TokenAmount) (*Trade, error) {
 }
 
 // NewTrade creates a new trade
+// the amounts are wrapped to the route path tokens when going through the pools, and the trade input and output
+// amounts are expressed in the route Input and Output, i.e. ETHER if it was supplied
 // nolint gocyclo
 func NewTrade(route *Route, amount *TokenAmount, tradeType constants.TradeType) (*Trade, error) {
 	amounts := make([]*TokenAmount, len(route.Path))
-	nextPairs := make([]*Pair, len(route.Pairs))
+	nextPools := make([]Pool, len(route.Pools))
 
 	if tradeType == constants.ExactInput {
 		if !route.Input.Currency.Equals(amount.Token.Currency) {
@@ -83,14 +98,18 @@ func NewTrade(route *Route, amount *TokenAmount, tradeType constants.TradeType)
 			return nil, ErrDiffToken
 		}
 
-		amounts[0] = amount
+		wrappedAmount, err := NewTokenAmount(route.Path[0], amount.Raw())
+		if err != nil {
+			return nil, err
+		}
+		amounts[0] = wrappedAmount
 		for i := 0; i < len(route.Path)-1; i++ {
-			outputAmount, nextPair, err := route.Pairs[i].GetOutputAmount(amounts[i])
+			outputAmount, nextPool, err := swapExactIn(route.Pools[i], amounts[i], i > 0)
 			if err != nil {
 				return nil, err
 			}
 			amounts[i+1] = outputAmount
-			nextPairs[i] = nextPair
+			nextPools[i] = nextPool
 		}
 	} else {
 		if !route.Output.Currency.Equals(amount.Token.Currency) {
@@ -100,32 +119,38 @@ func NewTrade(route *Route, amount *TokenAmount, tradeType constants.TradeType)
 			return nil, ErrDiffToken
 		}
 
-		amounts[len(amounts)-1] = amount
+		wrappedAmount, err := NewTokenAmount(route.Path[len(route.Path)-1], amount.Raw())
+		if err != nil {
+			return nil, err
+		}
+		amounts[len(amounts)-1] = wrappedAmount
 		for i := len(route.Path) - 1; i > 0; i-- {
-			inputAmount, nextPair, err := route.Pairs[i-1].GetInputAmount(amounts[i])
+			inputAmount, nextPool, err := swapExactOut(route.Pools[i-1], amounts[i], i > 1)
 			if err != nil {
 				return nil, err
 			}
 			amounts[i-1] = inputAmount
-			nextPairs[i-1] = nextPair
+			nextPools[i-1] = nextPool
 		}
 	}
 
-	nextRoute, err := NewRoute(nextPairs, route.Input, nil)
-	if err != nil {
-		return nil, err
-	}
-	nextMidPrice, err := NewPriceFromRoute(nextRoute)
+	nextRoute, err := NewPoolRoute(nextPools, route.Input, route.Output)
 	if err != nil {
 		return nil, err
 	}
 	inputAmount := amount
 	if tradeType == constants.ExactOutput {
-		inputAmount = amounts[0]
+		inputAmount, err = NewTokenAmount(route.Input, amounts[0].Raw())
+		if err != nil {
+			return nil, err
+		}
 	}
 	outputAmount := amount
 	if tradeType == constants.ExactInput {
-		outputAmount = amounts[len(amounts)-1]
+		outputAmount, err = NewTokenAmount(route.Output, amounts[len(amounts)-1].Raw())
+		if err != nil {
+			return nil, err
+		}
 	}
 	price := NewPrice(inputAmount.Currency, outputAmount.Currency, inputAmount.Raw(), outputAmount.Raw())
 	return &Trade{
@@ -134,7 +159,7 @@ func NewTrade(route *Route, amount *TokenAmount, tradeType constants.TradeType)
 		inputAmount:    inputAmount,
 		outputAmount:   outputAmount,
 		ExecutionPrice: price,
-		NextMidPrice:   nextMidPrice,
+		NextMidPrice:   nextRoute.MidPrice,
 		PriceImpact:    computePriceImpact(route.MidPrice, inputAmount, outputAmount),
 	}, nil
 }
@@ -158,19 +183,23 @@ func computePriceImpact(midPrice *Price, inputAmount, outputAmount *TokenAmount)
  * @param slippageTolerance tolerance of unfavorable slippage from the execution price of this trade
  */
 func (t *Trade) MinimumAmountOut(slippageTolerance *Percent) (*TokenAmount, error) {
+	return minimumAmountOut(t.TradeType, t.outputAmount, slippageTolerance)
+}
+
+func minimumAmountOut(tradeType constants.TradeType, outputAmount *TokenAmount, slippageTolerance *Percent) (*TokenAmount, error) {
 	if slippageTolerance.LessThan(ZeroFraction) {
 		return nil, ErrInvalidSlippageTolerance
 	}
 
-	if t.TradeType == constants.ExactOutput {
-		return t.outputAmount, nil
+	if tradeType == constants.ExactOutput {
+		return outputAmount, nil
 	}
 
 	slippageAdjustedAmountOut := NewFraction(constants.One, nil).
 		Add(slippageTolerance.Fraction).
 		Invert().
-		Multiply(NewFraction(t.outputAmount.Raw(), nil)).Quotient()
-	return NewTokenAmount(t.outputAmount.Token, slippageAdjustedAmountOut)
+		Multiply(NewFraction(outputAmount.Raw(), nil)).Quotient()
+	return NewTokenAmount(outputAmount.Token, slippageAdjustedAmountOut)
 }
 
 /**
@@ -178,16 +207,20 @@ func (t *Trade) MinimumAmountOut(slippageTolerance *Percent) (*TokenAmount, erro
  * @param slippageTolerance tolerance of unfavorable slippage from the execution price of this trade
  */
 func (t *Trade) MaximumAmountIn(slippageTolerance *Percent) (*TokenAmount, error) {
+	return maximumAmountIn(t.TradeType, t.inputAmount, slippageTolerance)
+}
+
+func maximumAmountIn(tradeType constants.TradeType, inputAmount *TokenAmount, slippageTolerance *Percent) (*TokenAmount, error) {
 	if slippageTolerance.LessThan(ZeroFraction) {
 		return nil, ErrInvalidSlippageTolerance
 	}
 
-	if t.TradeType == constants.ExactInput {
-		return t.inputAmount, nil
+	if tradeType == constants.ExactInput {
+		return inputAmount, nil
 	}
 
 	slippageAdjustedAmountIn := NewFraction(constants.One, nil).
 		Add(slippageTolerance.Fraction).
-		Multiply(NewFraction(t.inputAmount.Raw(), nil)).Quotient()
-	return NewTokenAmount(t.inputAmount.Token, slippageAdjustedAmountIn)
+		Multiply(NewFraction(inputAmount.Raw(), nil)).Quotient()
+	return NewTokenAmount(inputAmount.Token, slippageAdjustedAmountIn)
 }
@@ -16,6 +16,8 @@ type BestTradeOptions struct {
 	MaxNumResults int
 	// the maximum number of hops a trade should contain
 	MaxHops int
+	// ranks the trades by their outputs net of gas if set, and estimates the gas costs of the returned trades
+	Gas *GasOptions
 }
 
 func NewDefaultBestTradeOptions() *BestTradeOptions {
@@ -29,9 +31,23 @@ func (o *BestTradeOptions) ReduceHops() *BestTradeOptions {
 	return &BestTradeOptions{
 		MaxNumResults: o.MaxNumResults,
 		MaxHops:       o.MaxHops - 1,
+		Gas:           o.Gas,
 	}
 }
 
+// insert estimates the gas cost of the trade if needed and inserts it into the sorted trades
+func (o *BestTradeOptions) insert(trades []*Trade, trade *Trade) ([]*Trade, error) {
+	comparator := TradeComparator
+	if o.Gas != nil {
+		if err := o.Gas.estimate(trade); err != nil {
+			return nil, err
+		}
+		comparator = GasTradeComparator
+	}
+	trades, _, err := SortedInsert(trades, trade, o.MaxNumResults, comparator)
+	return trades, err
+}
+
 // minimal interface so the input output comparator may be shared across types
 type InputOutput interface {
 	InputAmount() *TokenAmount
@@ -130,7 +146,7 @@ func SortedInsert(items []*Trade, add *Trade, maxSize int, comparator func(a, b
  * Given a list of pairs, and a fixed amount in, returns the top `maxNumResults` trades that go from an input token
  * amount to an output token, making at most `maxHops` hops.
  * Note this does not consider aggregation, as routes are linear. It's possible a better route exists by splitting
- * the amount in among multiple routes.
+ * the amount in among multiple routes, see BestSplitTradeExactIn.
  * @param pairs the pairs to consider in finding the best trade
  * @param currencyAmountIn exact amount of input currency to spend
  * @param currencyOut the desired currency out
@@ -143,12 +159,26 @@ func SortedInsert(items []*Trade, add *Trade, maxSize int, comparator func(a, b
 func BestTradeExactIn(
 	pairs []*Pair,
 	currencyAmountIn *TokenAmount,
-	currencyOut *Token,
+	currencyOut Asset,
 	options *BestTradeOptions,
 	// used in recursion.
 	currentPairs []*Pair,
 	originalAmountIn *TokenAmount,
 	bestTrades []*Trade,
+) (sortedItems []*Trade, err error) {
+	return bestTradeExactIn(PairPools(pairs), currencyAmountIn, currencyOut, options, PairPools(currentPairs),
+		originalAmountIn, bestTrades)
+}
+
+func bestTradeExactIn(
+	pools []Pool,
+	currencyAmountIn *TokenAmount,
+	currencyOut Asset,
+	options *BestTradeOptions,
+	// used in recursion.
+	currentPools []Pool,
+	originalAmountIn *TokenAmount,
+	bestTrades []*Trade,
 ) (sortedItems []*Trade, err error) {
 	if originalAmountIn == nil {
 		originalAmountIn = currencyAmountIn
@@ -157,31 +187,32 @@ func BestTradeExactIn(
 		options = NewDefaultBestTradeOptions()
 	}
 
-	if len(pairs) == 0 {
+	if len(pools) == 0 {
 		return nil, ErrInvalidPairs
 	}
 	if options == nil || options.MaxHops <= 0 {
 		return nil, ErrInvalidOption
 	}
-	if !(originalAmountIn == currencyAmountIn || len(currentPairs) > 0) {
+	if !(originalAmountIn == currencyAmountIn || len(currentPools) > 0) {
 		return nil, ErrInvalidRecursion
 	}
 
-	amountIn, tokenOut := currencyAmountIn, currencyOut
-	for i := 0; i < len(pairs); i++ {
-		pair := pairs[i]
-		// pair irrelevant
-		if !pair.Token0().Equals(amountIn.Token) && !pair.Token1().Equals(amountIn.Token) {
-			continue
-		}
-		if pair.Reserve0().EqualTo(ZeroFraction) || pair.Reserve1().EqualTo(ZeroFraction) {
+	tokenOut, err := currencyOut.Wrapped(currencyAmountIn.Token.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	amountIn := currencyAmountIn
+	for i := 0; i < len(pools); i++ {
+		pool := pools[i]
+		// pool irrelevant
+		if !pool.InvolvesToken(amountIn.Token) {
 			continue
 		}
 
-		amountOut, _, err := pair.GetOutputAmount(amountIn)
+		amountOut, _, err := swapExactIn(pool, amountIn, len(currentPools) > 0)
 		if err != nil {
-			// input too low
-			if err == ErrInsufficientInputAmount {
+			// input too low or no liquidity in this pool
+			if err == ErrInsufficientInputAmount || err == ErrInsufficientReserves {
 				continue
 			}
 			return nil, err
@@ -190,7 +221,7 @@ func BestTradeExactIn(
 		// we have arrived at the output token, so this is the final trade of one of the paths
 		if amountOut.Token.Equals(tokenOut) {
 			var route *Route
-			route, err = NewRoute(append(currentPairs, pair), originalAmountIn.Token, currencyOut)
+			route, err = NewPoolRoute(append(currentPools, pool), originalAmountIn.Token, currencyOut)
 			if err != nil {
 				return nil, err
 			}
@@ -199,7 +230,7 @@ func BestTradeExactIn(
 			if err != nil {
 				return nil, err
 			}
-			bestTrades, _, err = SortedInsert(bestTrades, trade, options.MaxNumResults, TradeComparator)
+			bestTrades, err = options.insert(bestTrades, trade)
 			if err != nil {
 				return nil, err
 			}
@@ -207,16 +238,16 @@ func BestTradeExactIn(
 		}
 
 		// otherwise, consider all the other paths that lead from this token as long as we have not exceeded maxHops
-		if options.MaxHops > 1 && len(pairs) > 1 {
-			pairsExcludingThisPair := make([]*Pair, len(pairs)-1)
-			copy(pairsExcludingThisPair, pairs[:i])
-			copy(pairsExcludingThisPair[i:], pairs[i+1:])
-			bestTrades, err = BestTradeExactIn(
-				pairsExcludingThisPair,
+		if options.MaxHops > 1 && len(pools) > 1 {
+			poolsExcludingThisPool := make([]Pool, len(pools)-1)
+			copy(poolsExcludingThisPool, pools[:i])
+			copy(poolsExcludingThisPool[i:], pools[i+1:])
+			bestTrades, err = bestTradeExactIn(
+				poolsExcludingThisPool,
 				amountOut,
 				currencyOut,
 				options.ReduceHops(),
-				append(currentPairs, pair),
+				append(currentPools, pool),
 				originalAmountIn,
 				bestTrades,
 			)
@@ -234,7 +265,7 @@ func BestTradeExactIn(
  * given a list of pairs, and a fixed amount out, returns the top `maxNumResults` trades that go from an input token
  * to an output token amount, making at most `maxHops` hops
  * note this does not consider aggregation, as routes are linear. it's possible a better route exists by splitting
- * the amount in among multiple routes.
+ * the amount in among multiple routes, see BestSplitTradeExactOut.
  * @param pairs the pairs to consider in finding the best trade
  * @param currencyIn the currency to spend
  * @param currencyAmountOut the exact amount of currency out
@@ -246,13 +277,27 @@ func BestTradeExactIn(
  */
 func BestTradeExactOut(
 	pairs []*Pair,
-	currencyIn *Token,
+	currencyIn Asset,
 	currencyAmountOut *TokenAmount,
 	options *BestTradeOptions,
 	// used in recursion.
 	currentPairs []*Pair,
 	originalAmountOut *TokenAmount,
 	bestTrades []*Trade,
+) (sortedItems []*Trade, err error) {
+	return bestTradeExactOut(PairPools(pairs), currencyIn, currencyAmountOut, options, PairPools(currentPairs),
+		originalAmountOut, bestTrades)
+}
+
+func bestTradeExactOut(
+	pools []Pool,
+	currencyIn Asset,
+	currencyAmountOut *TokenAmount,
+	options *BestTradeOptions,
+	// used in recursion.
+	currentPools []Pool,
+	originalAmountOut *TokenAmount,
+	bestTrades []*Trade,
 ) (sortedItems []*Trade, err error) {
 	if originalAmountOut == nil {
 		originalAmountOut = currencyAmountOut
@@ -261,30 +306,36 @@ func BestTradeExactOut(
 		options = NewDefaultBestTradeOptions()
 	}
 
-	if len(pairs) == 0 {
+	if len(pools) == 0 {
 		return nil, ErrInvalidPairs
 	}
 	if options == nil || options.MaxHops <= 0 {
 		return nil, ErrInvalidOption
 	}
-	if !(originalAmountOut == currencyAmountOut || len(currentPairs) > 0) {
+	if !(originalAmountOut == currencyAmountOut || len(currentPools) > 0) {
 		return nil, ErrInvalidRecursion
 	}
 
-	amountOut, tokenIn := currencyAmountOut, currencyIn
-	for i := 0; i < len(pairs); i++ {
-		pair := pairs[i]
-		// pair irrelevant
-		if !pair.Token0().Equals(amountOut.Token) && !pair.Token1().Equals(amountOut.Token) {
-			continue
-		}
-		if pair.Reserve0().EqualTo(ZeroFraction) || pair.Reserve1().EqualTo(ZeroFraction) {
+	tokenIn, err := currencyIn.Wrapped(currencyAmountOut.Token.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	amountOut := currencyAmountOut
+	for i := 0; i < len(pools); i++ {
+		pool := pools[i]
+		// pool irrelevant
+		if !pool.InvolvesToken(amountOut.Token) {
 			continue
 		}
 
-		amountIn, _, err := pair.GetInputAmount(amountOut)
+		// the input of the pool is sent by the previous pool unless it is the input token
+		inputToken := pool.Token0()
+		if amountOut.Token.Equals(inputToken) {
+			inputToken = pool.Token1()
+		}
+		amountIn, _, err := swapExactOut(pool, amountOut, !inputToken.Equals(tokenIn))
 		if err != nil {
-			// not enough liquidity in this pair
+			// not enough liquidity in this pool
 			if err == ErrInsufficientReserves {
 				continue
 			}
@@ -294,7 +345,7 @@ func BestTradeExactOut(
 		// we have arrived at the input token, so this is the first trade of one of the paths
 		if amountIn.Token.Equals(tokenIn) {
 			var route *Route
-			route, err = NewRoute(append([]*Pair{pair}, currentPairs...), currencyIn, originalAmountOut.Token)
+			route, err = NewPoolRoute(append([]Pool{pool}, currentPools...), currencyIn, originalAmountOut.Token)
 			if err != nil {
 				return nil, err
 			}
@@ -303,7 +354,7 @@ func BestTradeExactOut(
 			if err != nil {
 				return nil, err
 			}
-			bestTrades, _, err = SortedInsert(bestTrades, trade, options.MaxNumResults, TradeComparator)
+			bestTrades, err = options.insert(bestTrades, trade)
 			if err != nil {
 				return nil, err
 			}
@@ -311,16 +362,16 @@ func BestTradeExactOut(
 		}
 
 		// otherwise, consider all the other paths that arrive at this token as long as we have not exceeded maxHops
-		if options.MaxHops > 1 && len(pairs) > 1 {
-			pairsExcludingThisPair := make([]*Pair, len(pairs)-1)
-			copy(pairsExcludingThisPair, pairs[:i])
-			copy(pairsExcludingThisPair[i:], pairs[i+1:])
-			bestTrades, err = BestTradeExactOut(
-				pairsExcludingThisPair,
+		if options.MaxHops > 1 && len(pools) > 1 {
+			poolsExcludingThisPool := make([]Pool, len(pools)-1)
+			copy(poolsExcludingThisPool, pools[:i])
+			copy(poolsExcludingThisPool[i:], pools[i+1:])
+			bestTrades, err = bestTradeExactOut(
+				poolsExcludingThisPool,
 				currencyIn,
 				amountIn,
 				options.ReduceHops(),
-				append([]*Pair{pair}, currentPairs...),
+				append([]Pool{pool}, currentPools...),
 				originalAmountOut,
 				bestTrades,
 			)
@@ -0,0 +1,92 @@
+package entities
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+)
+
+// ErrInvalidGasOptions the gas options miss a gas price, gas or gas token price
+var ErrInvalidGasOptions = fmt.Errorf("invalid gas options")
+
+// GasOptions the gas costs used to rank trades by their outputs net of gas
+type GasOptions struct {
+	// the gas price in wei
+	GasPrice *big.Int
+	// the estimated gas used by a swap besides its hops, and by each hop of the swap
+	BaseGas *big.Int
+	HopGas  *big.Int
+	// the price of the gas token, i.e. ETHER or WETH, in the output currency for exact input trades
+	// and in the input currency for exact output trades
+	GasTokenPrice *Price
+}
+
+// estimate sets the estimated gas and gas cost of the trade
+// @throws ErrInvalidGasOptions if any of the options is nil
+// @throws ErrInvalidCurrency if the gas token price is not from ETHER or WETH, or not in the currency of the cost
+func (o *GasOptions) estimate(trade *Trade) error {
+	if o.GasPrice == nil || o.BaseGas == nil || o.HopGas == nil || o.GasTokenPrice == nil {
+		return ErrInvalidGasOptions
+	}
+
+	costToken := trade.outputAmount.Token
+	if trade.TradeType == constants.ExactOutput {
+		costToken = trade.inputAmount.Token
+	}
+	if !isGasCurrency(o.GasTokenPrice.BaseCurrency, costToken.ChainID) ||
+		!o.GasTokenPrice.QuoteCurrency.Equals(costToken.Currency) {
+		return ErrInvalidCurrency
+	}
+
+	gas := big.NewInt(int64(len(trade.Route.Pools)))
+	gas.Mul(gas, o.HopGas)
+	gas.Add(gas, o.BaseGas)
+
+	wei := big.NewInt(0).Mul(gas, o.GasPrice)
+	cost, err := NewTokenAmount(costToken, o.GasTokenPrice.Raw().Multiply(NewFraction(wei, nil)).Quotient())
+	if err != nil {
+		return err
+	}
+	trade.EstimatedGas = gas
+	trade.GasCost = cost
+	return nil
+}
+
+// isGasCurrency returns true if the currency is ETHER or the WETH of the chain
+func isGasCurrency(currency *Currency, chainID constants.ChainID) bool {
+	if currency == nil {
+		return false
+	}
+	if currency.Equals(ETHER) {
+		return true
+	}
+	weth, ok := WETH[chainID]
+	return ok && currency.Equals(weth.Currency)
+}
+
+// GasTradeComparator ranks trades by their outputs net of the gas costs for exact input trades, and by their inputs
+// including the gas costs for exact output trades, then falls back to TradeComparator.
+// the gas costs are only considered if both trades are estimated, see BestTradeOptions.Gas
+func GasTradeComparator(a, b *Trade) int {
+	if a.GasCost == nil || b.GasCost == nil {
+		return TradeComparator(a, b)
+	}
+
+	var comp int
+	if a.TradeType == constants.ExactInput {
+		netA := big.NewInt(0).Sub(a.outputAmount.Raw(), a.GasCost.Raw())
+		netB := big.NewInt(0).Sub(b.outputAmount.Raw(), b.GasCost.Raw())
+		// more net output comes first
+		comp = netB.Cmp(netA)
+	} else {
+		netA := big.NewInt(0).Add(a.inputAmount.Raw(), a.GasCost.Raw())
+		netB := big.NewInt(0).Add(b.inputAmount.Raw(), b.GasCost.Raw())
+		// less net input comes first
+		comp = netA.Cmp(netB)
+	}
+	if comp != 0 {
+		return comp
+	}
+	return TradeComparator(a, b)
+}
@@ -0,0 +1,143 @@
+package entities
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+)
+
+// nolint funlen
+func TestGasTradeComparator(t *testing.T) {
+	tokenA, _ := NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000001"), 18, "A", "")
+	tokenB, _ := NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000002"), 18, "B", "")
+	tokenT, _ := NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000003"), 18, "T", "")
+
+	pair_a_t, _ := NewPair(mustTokenAmount(t, tokenA, 100000), mustTokenAmount(t, tokenT, 100000))
+	pair_t_b, _ := NewPair(mustTokenAmount(t, tokenT, 100000), mustTokenAmount(t, tokenB, 100000))
+	pair_a_b, _ := NewPair(mustTokenAmount(t, tokenA, 1000), mustTokenAmount(t, tokenB, 1000))
+	pairs := []*Pair{pair_a_t, pair_t_b, pair_a_b}
+	graph := NewPairGraph(pairs)
+
+	// 100 gwei, 150000 gas for one hop and 200000 gas for two hops
+	newOptions := func(gasTokenPrice *Price) *BestTradeOptions {
+		return &BestTradeOptions{
+			MaxNumResults: 3,
+			MaxHops:       3,
+			Gas: &GasOptions{
+				GasPrice:      big.NewInt(100000000000),
+				BaseGas:       big.NewInt(100000),
+				HopGas:        big.NewInt(50000),
+				GasTokenPrice: gasTokenPrice,
+			},
+		}
+	}
+	// a gas cost of 45 for one hop and 60 for two hops
+	gasPrice := big.NewInt(1000000000000000)
+
+	// the extra hop costs more than the improved output
+	{
+		options := newOptions(NewPrice(ETHER, tokenB.Currency, gasPrice, big.NewInt(3)))
+		trades, err := BestTradeExactIn(pairs, mustTokenAmount(t, tokenA, 100), tokenB, options, nil, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(trades) != 2 || len(trades[0].Route.Pairs) != 1 {
+			t.Fatalf("expect the direct trade first, but got[%+v]", trades)
+		}
+		if output := trades[0].OutputAmount().Raw().String(); output != "90" {
+			t.Errorf("expect[%+v], but got[%+v]", "90", output)
+		}
+		if output := trades[0].EstimatedGas.String(); output != "150000" {
+			t.Errorf("expect[%+v], but got[%+v]", "150000", output)
+		}
+		if output := trades[1].GasCost.Raw().String(); output != "60" || !trades[1].GasCost.Token.Equals(tokenB) {
+			t.Errorf("expect[%+v], but got[%+v]", "60", output)
+		}
+
+		graphTrades, err := graph.BestTradeExactIn(mustTokenAmount(t, tokenA, 100), tokenB, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertSameTrades(t, trades, graphTrades)
+
+		// the output only ranks the trade through T first
+		trades, err = BestTradeExactIn(pairs, mustTokenAmount(t, tokenA, 100), tokenB, nil, nil, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(trades[0].Route.Pairs) != 2 || trades[0].GasCost != nil {
+			t.Errorf("expect the trade through T first, but got[%+v]", trades[0].Route.Path)
+		}
+	}
+
+	// cheap gas keeps the better output first
+	{
+		options := newOptions(NewPrice(ETHER, tokenB.Currency, gasPrice, big.NewInt(0)))
+		trades, err := BestTradeExactIn(pairs, mustTokenAmount(t, tokenA, 100), tokenB, options, nil, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(trades[0].Route.Pairs) != 2 {
+			t.Errorf("expect the trade through T first, but got[%+v]", trades[0].Route.Path)
+		}
+	}
+
+	// ranks exact output trades by the input including gas
+	{
+		options := newOptions(NewPrice(ETHER, tokenA.Currency, gasPrice, big.NewInt(3)))
+		trades, err := BestTradeExactOut(pairs, tokenA, mustTokenAmount(t, tokenB, 90), options, nil, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(trades) != 2 || len(trades[0].Route.Pairs) != 1 {
+			t.Fatalf("expect the direct trade first, but got[%+v]", trades)
+		}
+		if !trades[0].GasCost.Token.Equals(tokenA) {
+			t.Errorf("expect[%+v], but got[%+v]", tokenA, trades[0].GasCost.Token)
+		}
+	}
+
+	// throws if the gas token price is not in the output currency
+	{
+		options := newOptions(NewPrice(ETHER, tokenA.Currency, gasPrice, big.NewInt(3)))
+		_, output := BestTradeExactIn(pairs, mustTokenAmount(t, tokenA, 100), tokenB, options, nil, nil, nil)
+		if output != ErrInvalidCurrency {
+			t.Errorf("expect[%+v], but got[%+v]", ErrInvalidCurrency, output)
+		}
+	}
+
+	// throws if the gas token price is not from ETHER or WETH
+	{
+		options := newOptions(NewPrice(tokenT.Currency, tokenB.Currency, gasPrice, big.NewInt(3)))
+		_, output := BestTradeExactIn(pairs, mustTokenAmount(t, tokenA, 100), tokenB, options, nil, nil, nil)
+		if output != ErrInvalidCurrency {
+			t.Errorf("expect[%+v], but got[%+v]", ErrInvalidCurrency, output)
+		}
+
+		options = newOptions(NewPrice(WETH[constants.Mainnet].Currency, tokenB.Currency, gasPrice, big.NewInt(3)))
+		if _, err := BestTradeExactIn(pairs, mustTokenAmount(t, tokenA, 100), tokenB, options, nil, nil, nil); err != nil {
+			t.Errorf("expect[%+v], but got[%+v]", nil, err)
+		}
+	}
+
+	// throws if any of the gas options is nil
+	{
+		tests := []func(*GasOptions){
+			func(o *GasOptions) { o.GasPrice = nil },
+			func(o *GasOptions) { o.BaseGas = nil },
+			func(o *GasOptions) { o.HopGas = nil },
+			func(o *GasOptions) { o.GasTokenPrice = nil },
+		}
+		for i, unset := range tests {
+			options := newOptions(NewPrice(ETHER, tokenB.Currency, gasPrice, big.NewInt(3)))
+			unset(options.Gas)
+			_, output := BestTradeExactIn(pairs, mustTokenAmount(t, tokenA, 100), tokenB, options, nil, nil, nil)
+			if output != ErrInvalidGasOptions {
+				t.Errorf("test #%d: expect[%+v], but got[%+v]", i, ErrInvalidGasOptions, output)
+			}
+		}
+	}
+}
@@ -0,0 +1,389 @@
+package entities
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+)
+
+var (
+	ErrInvalidSplitOption = fmt.Errorf("invalid split trade option")
+	ErrNoTrade            = fmt.Errorf("no trade")
+)
+
+type SplitTradeOptions struct {
+	// the amount is split among the routes in steps of this percent, it must divide 100
+	Step int
+	// the maximum number of routes a trade is split among
+	MaxSplits int
+	// the maximum number of candidate routes to consider
+	MaxRoutes int
+	// the maximum number of hops a route should contain
+	MaxHops int
+}
+
+func NewDefaultSplitTradeOptions() *SplitTradeOptions {
+	return &SplitTradeOptions{
+		Step:      5,
+		MaxSplits: 3,
+		MaxRoutes: 10,
+		MaxHops:   3,
+	}
+}
+
+func (o *SplitTradeOptions) valid() bool {
+	return o.Step > 0 && o.Step <= 100 && 100%o.Step == 0 && o.MaxSplits > 0 && o.MaxRoutes > 0 && o.MaxHops > 0
+}
+
+// SplitTrade Represents a trade whose amount is split among multiple routes, which do not share any pair.
+// Does not account for slippage, i.e. trades that front run this trade and move the price.
+type SplitTrade struct {
+	/**
+	 * The type of the trade, either exact in or exact out.
+	 */
+	TradeType constants.TradeType
+	/**
+	 * The trade of each route, whose amounts add up to the amounts of the split trade.
+	 */
+	Trades []*Trade
+	/**
+	 * The input amount for the trade assuming no slippage.
+	 */
+	inputAmount *TokenAmount
+	/**
+	 * The output amount for the trade assuming no slippage.
+	 */
+	outputAmount *TokenAmount
+	/**
+	 * The price expressed in terms of output amount/input amount.
+	 */
+	ExecutionPrice *Price
+	/**
+	 * The percent difference between the mid prices before the trade and the trade execution price,
+	 * weighted by the amounts of each route.
+	 */
+	PriceImpact *Percent
+}
+
+func (t *SplitTrade) InputAmount() *TokenAmount {
+	return t.inputAmount
+}
+
+func (t *SplitTrade) OutputAmount() *TokenAmount {
+	return t.outputAmount
+}
+
+// NewSplitTrade creates a split trade from the trades of each route, which must have the same type, input and output
+func NewSplitTrade(trades []*Trade) (*SplitTrade, error) {
+	if len(trades) == 0 {
+		return nil, ErrNoTrade
+	}
+
+	tradeType := trades[0].TradeType
+	inputAmount, outputAmount := trades[0].inputAmount, trades[0].outputAmount
+	exactQuote := trades[0].Route.MidPrice.Raw().Multiply(NewFraction(inputAmount.Raw(), nil))
+	var err error
+	for _, trade := range trades[1:] {
+		if trade.TradeType != tradeType ||
+			!trade.inputAmount.Currency.Equals(inputAmount.Currency) ||
+			!trade.outputAmount.Currency.Equals(outputAmount.Currency) {
+			return nil, ErrInvalidCurrency
+		}
+
+		inputAmount, err = inputAmount.Add(trade.inputAmount)
+		if err != nil {
+			return nil, err
+		}
+		outputAmount, err = outputAmount.Add(trade.outputAmount)
+		if err != nil {
+			return nil, err
+		}
+		exactQuote = exactQuote.Add(trade.Route.MidPrice.Raw().Multiply(NewFraction(trade.inputAmount.Raw(), nil)))
+	}
+
+	slippage := exactQuote.Subtract(NewFraction(outputAmount.Raw(), nil)).Divide(exactQuote)
+	return &SplitTrade{
+		TradeType:      tradeType,
+		Trades:         trades,
+		inputAmount:    inputAmount,
+		outputAmount:   outputAmount,
+		ExecutionPrice: NewPrice(inputAmount.Currency, outputAmount.Currency, inputAmount.Raw(), outputAmount.Raw()),
+		PriceImpact:    &Percent{Fraction: slippage},
+	}, nil
+}
+
+/**
+ * Returns the share of the amount of each route, i.e. of the input amount for exact in and of the output amount
+ * for exact out.
+ */
+func (t *SplitTrade) Percents() []*Percent {
+	percents := make([]*Percent, len(t.Trades))
+	for i, trade := range t.Trades {
+		if t.TradeType == constants.ExactInput {
+			percents[i] = NewPercent(trade.inputAmount.Raw(), t.inputAmount.Raw())
+		} else {
+			percents[i] = NewPercent(trade.outputAmount.Raw(), t.outputAmount.Raw())
+		}
+	}
+	return percents
+}
+
+/**
+ * Get the minimum amount that must be received from this trade for the given slippage tolerance
+ * @param slippageTolerance tolerance of unfavorable slippage from the execution price of this trade
+ */
+func (t *SplitTrade) MinimumAmountOut(slippageTolerance *Percent) (*TokenAmount, error) {
+	return minimumAmountOut(t.TradeType, t.outputAmount, slippageTolerance)
+}
+
+/**
+ * Get the maximum amount in that can be spent via this trade for the given slippage tolerance
+ * @param slippageTolerance tolerance of unfavorable slippage from the execution price of this trade
+ */
+func (t *SplitTrade) MaximumAmountIn(slippageTolerance *Percent) (*TokenAmount, error) {
+	return maximumAmountIn(t.TradeType, t.inputAmount, slippageTolerance)
+}
+
+/**
+ * Given a list of pairs, and a fixed amount in, returns the trade that splits the amount in among at most
+ * `maxSplits` routes in `step` percent steps, which maximizes the total amount out.
+ * @param pairs the pairs to consider in finding the best trade
+ * @param currencyAmountIn exact amount of input currency to spend
+ * @param currencyOut the desired currency out
+ * @param options the split trade options
+ */
+func BestSplitTradeExactIn(pairs []*Pair, currencyAmountIn *TokenAmount, currencyOut Asset, options *SplitTradeOptions) (*SplitTrade, error) {
+	if options == nil {
+		options = NewDefaultSplitTradeOptions()
+	}
+	if !options.valid() {
+		return nil, ErrInvalidSplitOption
+	}
+
+	stepAmount, err := NewTokenAmount(currencyAmountIn.Token, splitAmount(currencyAmountIn.Raw(), options.Step, 100))
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := BestTradeExactIn(pairs, stepAmount, currencyOut,
+		&BestTradeOptions{MaxNumResults: options.MaxRoutes, MaxHops: options.MaxHops}, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return bestSplitTrade(candidates, currencyAmountIn, constants.ExactInput, options, func(a, b *big.Int) bool {
+		return a.Cmp(b) > 0
+	})
+}
+
+/**
+ * similar to the above method but instead targets a fixed output amount
+ * given a list of pairs, and a fixed amount out, returns the trade that splits the amount out among at most
+ * `maxSplits` routes in `step` percent steps, which minimizes the total amount in.
+ * @param pairs the pairs to consider in finding the best trade
+ * @param currencyIn the currency to spend
+ * @param currencyAmountOut the exact amount of currency out
+ * @param options the split trade options
+ */
+func BestSplitTradeExactOut(pairs []*Pair, currencyIn Asset, currencyAmountOut *TokenAmount, options *SplitTradeOptions) (*SplitTrade, error) {
+	if options == nil {
+		options = NewDefaultSplitTradeOptions()
+	}
+	if !options.valid() {
+		return nil, ErrInvalidSplitOption
+	}
+
+	stepAmount, err := NewTokenAmount(currencyAmountOut.Token, splitAmount(currencyAmountOut.Raw(), options.Step, 100))
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := BestTradeExactOut(pairs, currencyIn, stepAmount,
+		&BestTradeOptions{MaxNumResults: options.MaxRoutes, MaxHops: options.MaxHops}, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return bestSplitTrade(candidates, currencyAmountOut, constants.ExactOutput, options, func(a, b *big.Int) bool {
+		return a.Cmp(b) < 0
+	})
+}
+
+func splitAmount(amount *big.Int, parts, totalParts int) *big.Int {
+	z := big.NewInt(0).Mul(amount, big.NewInt(int64(parts)))
+	return z.Div(z, big.NewInt(int64(totalParts)))
+}
+
+// quoteOf returns the trade of the amount through the route, nil if the route can not trade the amount
+func quoteOf(route *Route, amount *TokenAmount, tradeType constants.TradeType) (*Trade, error) {
+	trade, err := NewTrade(route, amount, tradeType)
+	if err == ErrInsufficientInputAmount || err == ErrInsufficientReserves {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return trade, nil
+}
+
+// nolint gocyclo
+func bestSplitTrade(candidates []*Trade, amount *TokenAmount, tradeType constants.TradeType,
+	options *SplitTradeOptions, better func(a, b *big.Int) bool) (*SplitTrade, error) {
+	if len(candidates) == 0 {
+		return nil, ErrNoTrade
+	}
+
+	// quote returns the amount on the other side of the candidate for the raw amount, nil if it can not trade it
+	quote := func(candidate *Trade, raw *big.Int) (*big.Int, error) {
+		partAmount, err := NewTokenAmount(amount.Token, raw)
+		if err != nil {
+			return nil, err
+		}
+		trade, err := quoteOf(candidate.Route, partAmount, tradeType)
+		if err != nil || trade == nil {
+			return nil, err
+		}
+		if tradeType == constants.ExactInput {
+			return trade.outputAmount.Raw(), nil
+		}
+		return trade.inputAmount.Raw(), nil
+	}
+
+	// partAmounts[k] is k steps of the amount, quotes[i][k] is the amount on the other side of candidate i for it
+	totalParts := 100 / options.Step
+	partAmounts := make([]*big.Int, totalParts+1)
+	for k := 1; k <= totalParts; k++ {
+		partAmounts[k] = splitAmount(amount.Raw(), k, totalParts)
+	}
+	quotes := make([][]*big.Int, len(candidates))
+	pairAddresses := make([]map[common.Address]struct{}, len(candidates))
+	for i, candidate := range candidates {
+		quotes[i] = make([]*big.Int, totalParts+1)
+		for k := 1; k <= totalParts; k++ {
+			q, err := quote(candidate, partAmounts[k])
+			if err != nil {
+				return nil, err
+			}
+			quotes[i][k] = q
+		}
+
+		pairAddresses[i] = make(map[common.Address]struct{}, len(candidate.Route.Pairs))
+		for _, pair := range candidate.Route.Pairs {
+			pairAddresses[i][pair.GetAddress()] = struct{}{}
+		}
+	}
+
+	disjoint := func(routes []int, j int) bool {
+		for _, i := range routes {
+			for address := range pairAddresses[j] {
+				if _, ok := pairAddresses[i][address]; ok {
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	// the last route of a split takes the remainder of the amount, as the split trade is built below, so it is quoted
+	// with that remainder, which only differs from its steps of the amount by the rounding of the other routes
+	remainderQuotes := make([]map[string]*big.Int, len(candidates))
+	remainderQuote := func(i int, raw *big.Int) (*big.Int, error) {
+		if remainderQuotes[i] == nil {
+			remainderQuotes[i] = make(map[string]*big.Int)
+		}
+		key := raw.String()
+		if q, ok := remainderQuotes[i][key]; ok {
+			return q, nil
+		}
+		q, err := quote(candidates[i], raw)
+		if err != nil {
+			return nil, err
+		}
+		remainderQuotes[i][key] = q
+		return q, nil
+	}
+
+	var (
+		bestRoutes, bestParts []int
+		bestQuote             *big.Int
+		quoteErr              error
+	)
+	// distribute the remaining parts among the routes, every route gets at least one part
+	var distribute func(routes, parts []int, remaining int, allocated, total *big.Int)
+	distribute = func(routes, parts []int, remaining int, allocated, total *big.Int) {
+		if quoteErr != nil {
+			return
+		}
+		i := len(parts)
+		if i == len(routes)-1 {
+			q, err := remainderQuote(routes[i], big.NewInt(0).Sub(amount.Raw(), allocated))
+			if err != nil {
+				quoteErr = err
+				return
+			}
+			if q == nil {
+				return
+			}
+			total = big.NewInt(0).Add(total, q)
+			// prefer fewer routes on equal quotes, which are enumerated first
+			if bestQuote == nil || better(total, bestQuote) {
+				bestQuote = total
+				bestRoutes = append([]int{}, routes...)
+				bestParts = append(append([]int{}, parts...), remaining)
+			}
+			return
+		}
+		for k := 1; k <= remaining-(len(routes)-1-i); k++ {
+			q := quotes[routes[i]][k]
+			if q == nil {
+				continue
+			}
+			distribute(routes, append(parts, k), remaining-k, big.NewInt(0).Add(allocated, partAmounts[k]),
+				big.NewInt(0).Add(total, q))
+		}
+	}
+	// choose the sets of `size` routes which do not share any pair
+	var choose func(routes []int, start, size int)
+	choose = func(routes []int, start, size int) {
+		if len(routes) == size {
+			distribute(routes, nil, totalParts, big.NewInt(0), big.NewInt(0))
+			return
+		}
+		for j := start; j < len(candidates); j++ {
+			if disjoint(routes, j) {
+				choose(append(routes, j), j+1, size)
+			}
+		}
+	}
+	for size := 1; size <= options.MaxSplits && size <= totalParts; size++ {
+		choose(nil, 0, size)
+	}
+	if quoteErr != nil {
+		return nil, quoteErr
+	}
+	if bestQuote == nil {
+		return nil, ErrNoTrade
+	}
+
+	// the last route takes the remainder so that the amounts add up exactly
+	trades := make([]*Trade, len(bestRoutes))
+	remaining := amount.Raw()
+	for i, j := range bestRoutes {
+		raw := remaining
+		if i < len(bestRoutes)-1 {
+			raw = partAmounts[bestParts[i]]
+		}
+		remaining = big.NewInt(0).Sub(remaining, raw)
+
+		partAmount, err := NewTokenAmount(amount.Token, raw)
+		if err != nil {
+			return nil, err
+		}
+		trades[i], err = NewTrade(candidates[j].Route, partAmount, tradeType)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return NewSplitTrade(trades)
+}
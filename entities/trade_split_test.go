@@ -0,0 +1,183 @@
+package entities
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+)
+
+// nolint funlen
+func TestBestSplitTrade(t *testing.T) {
+	token0, _ := NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000001"), 18, "t0", "")
+	token1, _ := NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000002"), 18, "t1", "")
+	token2, _ := NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000003"), 18, "t2", "")
+
+	tokenAmount_0_1000, _ := NewTokenAmount(token0, big.NewInt(1000))
+	tokenAmount_1_1000, _ := NewTokenAmount(token1, big.NewInt(1000))
+	tokenAmount_2_1000, _ := NewTokenAmount(token2, big.NewInt(1000))
+	tokenAmount_0_200, _ := NewTokenAmount(token0, big.NewInt(200))
+	tokenAmount_1_100, _ := NewTokenAmount(token1, big.NewInt(100))
+
+	pair_0_1, _ := NewPair(tokenAmount_0_1000, tokenAmount_1_1000)
+	// the same tokens on another protocol
+	pancake_0_1, _ := NewPairWithProtocol(tokenAmount_0_1000, tokenAmount_1_1000, PancakeSwapV2)
+	pair_0_2, _ := NewPair(tokenAmount_0_1000, tokenAmount_2_1000)
+
+	// throws with invalid step
+	{
+		_, output := BestSplitTradeExactIn([]*Pair{pair_0_1}, tokenAmount_0_200, token1,
+			&SplitTradeOptions{Step: 7, MaxSplits: 3, MaxRoutes: 10, MaxHops: 3})
+		if output != ErrInvalidSplitOption {
+			t.Errorf("expect[%+v], but got[%+v]", ErrInvalidSplitOption, output)
+		}
+	}
+
+	// throws without any route
+	{
+		_, output := BestSplitTradeExactIn([]*Pair{pair_0_2}, tokenAmount_0_200, token1, nil)
+		if output != ErrNoTrade {
+			t.Errorf("expect[%+v], but got[%+v]", ErrNoTrade, output)
+		}
+	}
+
+	// single route when there is nothing to split among
+	{
+		trade, err := BestSplitTradeExactIn([]*Pair{pair_0_1, pair_0_2}, tokenAmount_0_200, token1, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(trade.Trades) != 1 {
+			t.Fatalf("expect[1], but got[%+v]", len(trade.Trades))
+		}
+		expect := big.NewInt(166)
+		if output := trade.OutputAmount().Raw(); output.Cmp(expect) != 0 {
+			t.Errorf("expect[%+v], but got[%+v]", expect, output)
+		}
+	}
+
+	pairs := []*Pair{pair_0_1, pancake_0_1, pair_0_2}
+	// splits an exact input among routes
+	{
+		trade, err := BestSplitTradeExactIn(pairs, tokenAmount_0_200, token1, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(trade.Trades) != 2 {
+			t.Fatalf("expect[2], but got[%+v]", len(trade.Trades))
+		}
+		// splitting the amount in outputs more than a single route
+		expect := big.NewInt(180)
+		if output := trade.OutputAmount().Raw(); output.Cmp(expect) != 0 {
+			t.Errorf("expect[%+v], but got[%+v]", expect, output)
+		}
+		if !trade.InputAmount().Equals(tokenAmount_0_200) {
+			t.Errorf("expect[%+v], but got[%+v]", tokenAmount_0_200.Raw(), trade.InputAmount().Raw())
+		}
+		// the lower fee pancake pair takes a bigger share
+		for i, percent := range trade.Percents() {
+			expect := NewPercent(big.NewInt(45), big.NewInt(100))
+			if trade.Trades[i].Route.Pairs[0].Protocol == PancakeSwapV2 {
+				expect = NewPercent(big.NewInt(55), big.NewInt(100))
+			}
+			if !expect.EqualTo(percent.Fraction) {
+				t.Errorf("test #%d: expect[%+v], but got[%+v]", i, expect.ToFixed(2), percent.ToFixed(2))
+			}
+		}
+		// (200 - 180) / 200
+		expectImpact := NewPercent(big.NewInt(1), big.NewInt(10))
+		if !trade.PriceImpact.EqualTo(expectImpact.Fraction) {
+			t.Errorf("expect[%+v], but got[%+v]", expectImpact.ToFixed(2), trade.PriceImpact.ToFixed(2))
+		}
+
+		minimumAmountOut, err := trade.MinimumAmountOut(NewPercent(big.NewInt(5), big.NewInt(100)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// 180 / 1.05
+		expect = big.NewInt(171)
+		if output := minimumAmountOut.Raw(); output.Cmp(expect) != 0 {
+			t.Errorf("expect[%+v], but got[%+v]", expect, output)
+		}
+	}
+
+	// splits an exact output among routes
+	{
+		trade, err := BestSplitTradeExactOut(pairs, token0, tokenAmount_1_100, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(trade.Trades) != 2 {
+			t.Fatalf("expect[2], but got[%+v]", len(trade.Trades))
+		}
+		expect := big.NewInt(106)
+		if output := trade.InputAmount().Raw(); output.Cmp(expect) != 0 {
+			t.Errorf("expect[%+v], but got[%+v]", expect, output)
+		}
+		if !trade.OutputAmount().Equals(tokenAmount_1_100) {
+			t.Errorf("expect[%+v], but got[%+v]", tokenAmount_1_100.Raw(), trade.OutputAmount().Raw())
+		}
+
+		maximumAmountIn, err := trade.MaximumAmountIn(NewPercent(big.NewInt(10), big.NewInt(100)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// 106 * 1.1
+		expect = big.NewInt(116)
+		if output := maximumAmountIn.Raw(); output.Cmp(expect) != 0 {
+			t.Errorf("expect[%+v], but got[%+v]", expect, output)
+		}
+	}
+
+	// respects max splits
+	{
+		trade, err := BestSplitTradeExactIn(pairs, tokenAmount_0_200, token1,
+			&SplitTradeOptions{Step: 10, MaxSplits: 1, MaxRoutes: 10, MaxHops: 3})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(trade.Trades) != 1 {
+			t.Fatalf("expect[1], but got[%+v]", len(trade.Trades))
+		}
+	}
+
+	// ranks the splits by the amounts the split trade is built with, i.e. the last route takes the remainder
+	{
+		amount, _ := NewTokenAmount(token0, big.NewInt(205))
+		options := &SplitTradeOptions{Step: 10, MaxSplits: 2, MaxRoutes: 10, MaxHops: 1}
+		trade, err := BestSplitTradeExactIn(pairs, amount, token1, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		outputOf := func(pair *Pair, raw *big.Int) *big.Int {
+			input, _ := NewTokenAmount(token0, raw)
+			output, _, err := pair.GetOutputAmount(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return output.Raw()
+		}
+		best := big.NewInt(0)
+		for _, pair := range []*Pair{pair_0_1, pancake_0_1} {
+			if output := outputOf(pair, amount.Raw()); output.Cmp(best) > 0 {
+				best = output
+			}
+		}
+		for _, routes := range [][2]*Pair{{pair_0_1, pancake_0_1}, {pancake_0_1, pair_0_1}} {
+			for k := 1; k < 100/options.Step; k++ {
+				first := splitAmount(amount.Raw(), k, 100/options.Step)
+				output := big.NewInt(0).Add(outputOf(routes[0], first),
+					outputOf(routes[1], big.NewInt(0).Sub(amount.Raw(), first)))
+				if output.Cmp(best) > 0 {
+					best = output
+				}
+			}
+		}
+		if output := trade.OutputAmount().Raw(); output.Cmp(best) != 0 {
+			t.Errorf("expect[%+v], but got[%+v]", best, output)
+		}
+	}
+}
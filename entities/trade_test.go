@@ -898,3 +898,222 @@ func TestTrade(t *testing.T) {
 		}
 	}
 }
+
+// nolint funlen
+func TestTradeEther(t *testing.T) {
+	token0, _ := NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000001"), 18, "t0", "")
+	token1, _ := NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000002"), 18, "t1", "")
+	weth := WETH[constants.Mainnet]
+
+	tokenAmount_0_1000, _ := NewTokenAmount(token0, big.NewInt(1000))
+	tokenAmount_1_1000, _ := NewTokenAmount(token1, big.NewInt(1000))
+	tokenAmount_weth_1000, _ := NewTokenAmount(weth, big.NewInt(1000))
+	pair_0_1, _ := NewPair(tokenAmount_0_1000, tokenAmount_1_1000)
+	pair_weth_0, _ := NewPair(tokenAmount_weth_1000, tokenAmount_0_1000)
+
+	etherAmount, _ := NewEtherAmount(constants.Mainnet, big.NewInt(100))
+	tokenAmount_1_100, _ := NewTokenAmount(token1, big.NewInt(100))
+
+	checkCurrencies := func(trade *Trade, input, output *Currency) {
+		t.Helper()
+		if trade.InputAmount().Currency != input {
+			t.Errorf("expect input[%+v], but got[%+v]", input, trade.InputAmount().Currency)
+		}
+		if trade.OutputAmount().Currency != output {
+			t.Errorf("expect output[%+v], but got[%+v]", output, trade.OutputAmount().Currency)
+		}
+		if trade.ExecutionPrice.BaseCurrency != input || trade.ExecutionPrice.QuoteCurrency != output {
+			t.Errorf("expect execution price in [%+v/%+v], but got[%+v/%+v]", input, output,
+				trade.ExecutionPrice.BaseCurrency, trade.ExecutionPrice.QuoteCurrency)
+		}
+		if trade.NextMidPrice.BaseCurrency != input || trade.NextMidPrice.QuoteCurrency != output {
+			t.Errorf("expect next mid price in [%+v/%+v], but got[%+v/%+v]", input, output,
+				trade.NextMidPrice.BaseCurrency, trade.NextMidPrice.QuoteCurrency)
+		}
+	}
+
+	routeEtherIn, err := NewRoute([]*Pair{pair_weth_0, pair_0_1}, ETHER, token1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	routeEtherOut, err := NewRoute([]*Pair{pair_0_1, pair_weth_0}, token1, ETHER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// can be constructed with ETHER as input
+	{
+		trade, err := ExactIn(routeEtherIn, etherAmount)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkCurrencies(trade, ETHER, token1.Currency)
+		expect := big.NewInt(82)
+		if output := trade.OutputAmount().Raw(); output.Cmp(expect) != 0 {
+			t.Errorf("expect[%+v], but got[%+v]", expect, output)
+		}
+		// the pairs keep the wrapped token
+		if !trade.Route.Pairs[0].Token0().Currency.Equals(_WETHCurrency) &&
+			!trade.Route.Pairs[0].Token1().Currency.Equals(_WETHCurrency) {
+			t.Error("pair should hold WETH")
+		}
+	}
+
+	// can be constructed with ETHER as input for exact output
+	{
+		trade, err := ExactOut(routeEtherIn, tokenAmount_1_100)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkCurrencies(trade, ETHER, token1.Currency)
+		expect := big.NewInt(127)
+		if output := trade.InputAmount().Raw(); output.Cmp(expect) != 0 {
+			t.Errorf("expect[%+v], but got[%+v]", expect, output)
+		}
+	}
+
+	// can be constructed with ETHER as output
+	{
+		trade, err := ExactOut(routeEtherOut, etherAmount)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkCurrencies(trade, token1.Currency, ETHER)
+	}
+
+	// can be constructed with ETHER as output for exact input
+	{
+		trade, err := ExactIn(routeEtherOut, tokenAmount_1_100)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkCurrencies(trade, token1.Currency, ETHER)
+	}
+
+	// WETH amounts are not ETHER amounts
+	{
+		wethAmount, _ := NewTokenAmount(weth, big.NewInt(100))
+		_, output := ExactIn(routeEtherIn, wethAmount)
+		if output != ErrInvalidCurrency {
+			t.Errorf("expect[%+v], but got[%+v]", ErrInvalidCurrency, output)
+		}
+	}
+
+	pairs := []*Pair{pair_weth_0, pair_0_1}
+	// best trade exact in with ETHER output
+	{
+		result, err := BestTradeExactIn(pairs, tokenAmount_1_100, ETHER, nil, nil, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(result) != 1 {
+			t.Fatalf("expect[1], but got[%+v]", len(result))
+		}
+		checkCurrencies(result[0], token1.Currency, ETHER)
+	}
+
+	// best trade exact out with ETHER input
+	{
+		result, err := BestTradeExactOut(pairs, ETHER, tokenAmount_1_100, nil, nil, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(result) != 1 {
+			t.Fatalf("expect[1], but got[%+v]", len(result))
+		}
+		checkCurrencies(result[0], ETHER, token1.Currency)
+		if !result[0].Route.Path[0].Equals(weth) || result[0].Route.Path[0].Currency != _WETHCurrency {
+			t.Errorf("expect path to start with WETH, but got[%+v]", result[0].Route.Path[0])
+		}
+	}
+}
+
+// nolint funlen
+func TestTradeTransferFees(t *testing.T) {
+	tokenA, _ := NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000001"), 18, "A", "")
+	tokenB, _ := NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000002"), 18, "B", "")
+	tokenT, _ := NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000003"), 18, "T", "")
+	taxedT, _ := NewToken(constants.Mainnet, tokenT.Address, 18, "T", "")
+	taxedT, err := taxedT.WithTransferFees(NewPercent(big.NewInt(10), big.NewInt(100)),
+		NewPercent(big.NewInt(10), big.NewInt(100)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newPairs := func(tokenT *Token) []*Pair {
+		pair_a_t, _ := NewPair(mustTokenAmount(t, tokenA, 100000), mustTokenAmount(t, tokenT, 100000))
+		pair_t_b, _ := NewPair(mustTokenAmount(t, tokenT, 100000), mustTokenAmount(t, tokenB, 100000))
+		pair_a_b, _ := NewPair(mustTokenAmount(t, tokenA, 1000), mustTokenAmount(t, tokenB, 1000))
+		return []*Pair{pair_a_t, pair_t_b, pair_a_b}
+	}
+	amountIn := mustTokenAmount(t, tokenA, 100)
+
+	// the transfer from the first pair into the second is a single transfer, only taxed by the buy fee
+	{
+		pairs := newPairs(taxedT)
+		route, err := NewRoute(pairs[:2], tokenA, tokenB)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !route.FeeOnTransfer() {
+			t.Error("should be fee on transfer")
+		}
+		trade, err := ExactIn(route, amountIn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !trade.FeeOnTransfer() {
+			t.Error("should be fee on transfer")
+		}
+		// 100 * 0.997 * 0.9 * 0.997
+		if output := trade.OutputAmount().Raw().String(); output != "89" {
+			t.Errorf("expect[%+v], but got[%+v]", "89", output)
+		}
+		// the first pair sends 90 T after the buy fee, which the second pair receives untaxed
+		amountT, _, err := pairs[0].GetOutputAmount(amountIn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if output := amountT.Raw().String(); output != "90" {
+			t.Errorf("expect[%+v], but got[%+v]", "90", output)
+		}
+		expect, _, err := newPairs(tokenT)[1].GetOutputAmount(mustTokenAmount(t, tokenT, 90))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if output := trade.OutputAmount(); output.Raw().Cmp(expect.Raw()) != 0 {
+			t.Errorf("expect[%+v], but got[%+v]", expect.Raw(), output.Raw())
+		}
+
+		// the exact output trade is taxed the same
+		exactOut, err := ExactOut(route, trade.OutputAmount())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if output := exactOut.InputAmount().Raw().String(); output != "100" {
+			t.Errorf("expect[%+v], but got[%+v]", "100", output)
+		}
+	}
+
+	// the best trade avoids the taxed token
+	{
+		trades, err := BestTradeExactIn(newPairs(tokenT), amountIn, tokenB, nil, nil, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(trades[0].Route.Path) != 3 || trades[0].FeeOnTransfer() {
+			t.Errorf("expect the untaxed route through T, but got[%+v]", trades[0].Route.Path)
+		}
+
+		trades, err = BestTradeExactIn(newPairs(taxedT), amountIn, tokenB, nil, nil, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(trades[0].Route.Path) != 2 || trades[0].FeeOnTransfer() {
+			t.Errorf("expect the direct route, but got[%+v]", trades[0].Route.Path)
+		}
+		if len(trades) != 2 || !trades[1].FeeOnTransfer() {
+			t.Errorf("expect the taxed route second, but got[%+v]", trades)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package events
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/miraclesu/uniswap-sdk-go/entities"
+)
+
+var (
+	// ErrUnknownEvent the log is not a known event
+	ErrUnknownEvent = errors.New("unknown event")
+	// ErrInvalidLog the log does not match the event
+	ErrInvalidLog = errors.New("invalid log")
+	// ErrInvalidPair the log is not emitted by the pair
+	ErrInvalidPair = errors.New("invalid pair")
+)
+
+// Swap is the Swap event of a pair
+type Swap struct {
+	Sender     common.Address
+	To         common.Address
+	Amount0In  *entities.TokenAmount
+	Amount1In  *entities.TokenAmount
+	Amount0Out *entities.TokenAmount
+	Amount1Out *entities.TokenAmount
+	Raw        types.Log
+}
+
+// Sync is the Sync event of a pair, emitted with the new reserves after every Mint, Burn and Swap
+type Sync struct {
+	Reserve0 *entities.TokenAmount
+	Reserve1 *entities.TokenAmount
+	Raw      types.Log
+}
+
+// Mint is the Mint event of a pair
+type Mint struct {
+	Sender  common.Address
+	Amount0 *entities.TokenAmount
+	Amount1 *entities.TokenAmount
+	Raw     types.Log
+}
+
+// Burn is the Burn event of a pair
+type Burn struct {
+	Sender  common.Address
+	To      common.Address
+	Amount0 *entities.TokenAmount
+	Amount1 *entities.TokenAmount
+	Raw     types.Log
+}
+
+// PairCreated is the PairCreated event of a factory
+type PairCreated struct {
+	Factory common.Address
+	Token0  common.Address
+	Token1  common.Address
+	Pair    common.Address
+	// Index is the number of pairs created by the factory, i.e. allPairs.length
+	Index *big.Int
+	Raw   types.Log
+}
+
+// Decode decodes a log emitted by the pair into a *Swap, *Sync, *Mint or *Burn
+func Decode(pair *entities.Pair, log types.Log) (interface{}, error) {
+	if len(log.Topics) == 0 {
+		return nil, ErrInvalidLog
+	}
+	event, err := PairABI.EventByID(log.Topics[0])
+	if err != nil {
+		return nil, ErrUnknownEvent
+	}
+
+	switch event.Name {
+	case "Swap":
+		return DecodeSwap(pair, log)
+	case "Sync":
+		return DecodeSync(pair, log)
+	case "Mint":
+		return DecodeMint(pair, log)
+	case "Burn":
+		return DecodeBurn(pair, log)
+	}
+	return nil, ErrUnknownEvent
+}
+
+// DecodeSwap decodes a Swap log emitted by the pair
+func DecodeSwap(pair *entities.Pair, log types.Log) (*Swap, error) {
+	values, err := unpack(pair, "Swap", log)
+	if err != nil {
+		return nil, err
+	}
+	amounts, err := tokenAmounts(pair, values...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Swap{
+		Sender:     topicAddress(log.Topics[1]),
+		To:         topicAddress(log.Topics[2]),
+		Amount0In:  amounts[0],
+		Amount1In:  amounts[1],
+		Amount0Out: amounts[2],
+		Amount1Out: amounts[3],
+		Raw:        log,
+	}, nil
+}
+
+// DecodeSync decodes a Sync log emitted by the pair
+func DecodeSync(pair *entities.Pair, log types.Log) (*Sync, error) {
+	values, err := unpack(pair, "Sync", log)
+	if err != nil {
+		return nil, err
+	}
+	amounts, err := tokenAmounts(pair, values...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Sync{
+		Reserve0: amounts[0],
+		Reserve1: amounts[1],
+		Raw:      log,
+	}, nil
+}
+
+// DecodeMint decodes a Mint log emitted by the pair
+func DecodeMint(pair *entities.Pair, log types.Log) (*Mint, error) {
+	values, err := unpack(pair, "Mint", log)
+	if err != nil {
+		return nil, err
+	}
+	amounts, err := tokenAmounts(pair, values...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Mint{
+		Sender:  topicAddress(log.Topics[1]),
+		Amount0: amounts[0],
+		Amount1: amounts[1],
+		Raw:     log,
+	}, nil
+}
+
+// DecodeBurn decodes a Burn log emitted by the pair
+func DecodeBurn(pair *entities.Pair, log types.Log) (*Burn, error) {
+	values, err := unpack(pair, "Burn", log)
+	if err != nil {
+		return nil, err
+	}
+	amounts, err := tokenAmounts(pair, values...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Burn{
+		Sender:  topicAddress(log.Topics[1]),
+		To:      topicAddress(log.Topics[2]),
+		Amount0: amounts[0],
+		Amount1: amounts[1],
+		Raw:     log,
+	}, nil
+}
+
+// DecodePairCreated decodes a PairCreated log emitted by a factory
+func DecodePairCreated(log types.Log) (*PairCreated, error) {
+	values, err := unpackEvent(FactoryABI.Events["PairCreated"], log)
+	if err != nil {
+		return nil, err
+	}
+
+	pair, ok := values[0].(common.Address)
+	if !ok {
+		return nil, ErrInvalidLog
+	}
+	index, ok := values[1].(*big.Int)
+	if !ok {
+		return nil, ErrInvalidLog
+	}
+	return &PairCreated{
+		Factory: log.Address,
+		Token0:  topicAddress(log.Topics[1]),
+		Token1:  topicAddress(log.Topics[2]),
+		Pair:    pair,
+		Index:   index,
+		Raw:     log,
+	}, nil
+}
+
+// Apply returns the pair with the reserves of the Sync event
+// @param pair the pair emitting the event, in any state before the event
+func (s *Sync) Apply(pair *entities.Pair) (*entities.Pair, error) {
+	if pair.GetAddress() != s.Raw.Address ||
+		!pair.Token0().Equals(s.Reserve0.Token) || !pair.Token1().Equals(s.Reserve1.Token) {
+		return nil, ErrInvalidPair
+	}
+	return entities.NewPairWithProtocol(s.Reserve0, s.Reserve1, pair.Protocol)
+}
+
+// unpack checks the log is the named event emitted by the pair and unpacks its non-indexed values
+func unpack(pair *entities.Pair, name string, log types.Log) ([]interface{}, error) {
+	if pair.GetAddress() != log.Address {
+		return nil, ErrInvalidPair
+	}
+	return unpackEvent(PairABI.Events[name], log)
+}
+
+// unpackEvent checks the topics of the log and unpacks its non-indexed values
+func unpackEvent(event abi.Event, log types.Log) ([]interface{}, error) {
+	indexed := 0
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed++
+		}
+	}
+	if len(log.Topics) != indexed+1 || log.Topics[0] != event.ID {
+		return nil, ErrInvalidLog
+	}
+
+	values, err := event.Inputs.NonIndexed().Unpack(log.Data)
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// tokenAmounts converts the raw values into the amounts of token0 and token1 in turn
+func tokenAmounts(pair *entities.Pair, values ...interface{}) ([]*entities.TokenAmount, error) {
+	tokens := []*entities.Token{pair.Token0(), pair.Token1()}
+	amounts := make([]*entities.TokenAmount, len(values))
+	for i, value := range values {
+		raw, ok := value.(*big.Int)
+		if !ok {
+			return nil, ErrInvalidLog
+		}
+		amount, err := entities.NewTokenAmount(tokens[i%2], raw)
+		if err != nil {
+			return nil, err
+		}
+		amounts[i] = amount
+	}
+	return amounts, nil
+}
+
+func topicAddress(topic common.Hash) common.Address {
+	return common.BytesToAddress(topic.Bytes())
+}
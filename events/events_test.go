@@ -0,0 +1,154 @@
+package events
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+	"github.com/miraclesu/uniswap-sdk-go/entities"
+)
+
+func newLog(t *testing.T, contract common.Address, event string, topics []common.Hash, values ...interface{}) types.Log {
+	e, ok := PairABI.Events[event]
+	if !ok {
+		e = FactoryABI.Events[event]
+	}
+	data, err := e.Inputs.NonIndexed().Pack(values...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return types.Log{
+		Address: contract,
+		Topics:  append([]common.Hash{e.ID}, topics...),
+		Data:    data,
+	}
+}
+
+// nolint funlen
+func TestDecode(t *testing.T) {
+	token0, _ := entities.NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000001"), 18, "t0", "")
+	token1, _ := entities.NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000002"), 18, "t1", "")
+	tokenAmount_0_100, _ := entities.NewTokenAmount(token0, big.NewInt(100))
+	tokenAmount_1_100, _ := entities.NewTokenAmount(token1, big.NewInt(100))
+	pair_0_1, _ := entities.NewPair(tokenAmount_0_100, tokenAmount_1_100)
+	pancake_0_1, _ := entities.NewPairWithProtocol(tokenAmount_0_100, tokenAmount_1_100, entities.PancakeSwapV2)
+
+	sender := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	to := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+
+	// decodes swap
+	{
+		log := newLog(t, pair_0_1.GetAddress(), "Swap", []common.Hash{sender.Hash(), to.Hash()},
+			big.NewInt(10), big.NewInt(0), big.NewInt(0), big.NewInt(9))
+		output, err := Decode(pair_0_1, log)
+		if err != nil {
+			t.Fatal(err)
+		}
+		swap, ok := output.(*Swap)
+		if !ok {
+			t.Fatalf("expect[*Swap], but got[%T]", output)
+		}
+		if swap.Sender != sender || swap.To != to {
+			t.Errorf("expect[%+v %+v], but got[%+v %+v]", sender, to, swap.Sender, swap.To)
+		}
+		if !swap.Amount0In.Token.Equals(token0) || swap.Amount0In.Raw().Int64() != 10 {
+			t.Errorf("expect[%+v], but got[%+v]", 10, swap.Amount0In.Raw())
+		}
+		if !swap.Amount1Out.Token.Equals(token1) || swap.Amount1Out.Raw().Int64() != 9 {
+			t.Errorf("expect[%+v], but got[%+v]", 9, swap.Amount1Out.Raw())
+		}
+	}
+
+	// decodes mint and burn
+	{
+		log := newLog(t, pair_0_1.GetAddress(), "Mint", []common.Hash{sender.Hash()}, big.NewInt(5), big.NewInt(6))
+		mint, err := DecodeMint(pair_0_1, log)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mint.Sender != sender || mint.Amount0.Raw().Int64() != 5 || mint.Amount1.Raw().Int64() != 6 {
+			t.Errorf("expect[%+v 5 6], but got[%+v %+v %+v]", sender, mint.Sender, mint.Amount0.Raw(), mint.Amount1.Raw())
+		}
+
+		log = newLog(t, pair_0_1.GetAddress(), "Burn", []common.Hash{sender.Hash(), to.Hash()}, big.NewInt(7), big.NewInt(8))
+		output, err := Decode(pair_0_1, log)
+		if err != nil {
+			t.Fatal(err)
+		}
+		burn, ok := output.(*Burn)
+		if !ok {
+			t.Fatalf("expect[*Burn], but got[%T]", output)
+		}
+		if burn.To != to || burn.Amount0.Raw().Int64() != 7 || burn.Amount1.Raw().Int64() != 8 {
+			t.Errorf("expect[%+v 7 8], but got[%+v %+v %+v]", to, burn.To, burn.Amount0.Raw(), burn.Amount1.Raw())
+		}
+	}
+
+	// applies sync
+	{
+		log := newLog(t, pancake_0_1.GetAddress(), "Sync", nil, big.NewInt(110), big.NewInt(91))
+		sync, err := DecodeSync(pancake_0_1, log)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pair, err := sync.Apply(pancake_0_1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pair.Reserve0().Raw().Int64() != 110 || pair.Reserve1().Raw().Int64() != 91 {
+			t.Errorf("expect[110 91], but got[%+v %+v]", pair.Reserve0().Raw(), pair.Reserve1().Raw())
+		}
+		if pair.Protocol != entities.PancakeSwapV2 {
+			t.Errorf("expect[%+v], but got[%+v]", entities.PancakeSwapV2.Name, pair.Protocol.Name)
+		}
+
+		_, output := sync.Apply(pair_0_1)
+		if output != ErrInvalidPair {
+			t.Errorf("expect[%+v], but got[%+v]", ErrInvalidPair, output)
+		}
+	}
+
+	// throws for a log of another pair
+	{
+		log := newLog(t, pancake_0_1.GetAddress(), "Sync", nil, big.NewInt(110), big.NewInt(91))
+		_, output := Decode(pair_0_1, log)
+		if output != ErrInvalidPair {
+			t.Errorf("expect[%+v], but got[%+v]", ErrInvalidPair, output)
+		}
+	}
+
+	// throws for unknown or malformed logs
+	{
+		_, output := Decode(pair_0_1, types.Log{Address: pair_0_1.GetAddress(), Topics: []common.Hash{{}}})
+		if output != ErrUnknownEvent {
+			t.Errorf("expect[%+v], but got[%+v]", ErrUnknownEvent, output)
+		}
+
+		log := newLog(t, pair_0_1.GetAddress(), "Mint", []common.Hash{sender.Hash()}, big.NewInt(5), big.NewInt(6))
+		_, output = DecodeBurn(pair_0_1, log)
+		if output != ErrInvalidLog {
+			t.Errorf("expect[%+v], but got[%+v]", ErrInvalidLog, output)
+		}
+	}
+}
+
+func TestDecodePairCreated(t *testing.T) {
+	token0 := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	token1 := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	pair := common.HexToAddress("0x00000000000000000000000000000000000000cc")
+
+	log := newLog(t, constants.FactoryAddress, "PairCreated", []common.Hash{token0.Hash(), token1.Hash()}, pair, big.NewInt(42))
+	output, err := DecodePairCreated(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if output.Factory != constants.FactoryAddress || output.Token0 != token0 || output.Token1 != token1 || output.Pair != pair {
+		t.Errorf("expect[%+v %+v %+v], but got[%+v]", token0, token1, pair, output)
+	}
+	if output.Index.Int64() != 42 {
+		t.Errorf("expect[%+v], but got[%+v]", 42, output.Index)
+	}
+}
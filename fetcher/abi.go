@@ -0,0 +1,42 @@
+package fetcher
+
+import (
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+const (
+	// ref: https://eips.ethereum.org/EIPS/eip-20
+	erc20ABI = `[
+	{"type":"function","name":"decimals","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint8"}]},
+	{"type":"function","name":"symbol","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"string"}]},
+	{"type":"function","name":"name","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"string"}]}
+]`
+	// some early tokens such as MKR return bytes32 instead of string for symbol and name
+	erc20Bytes32ABI = `[
+	{"type":"function","name":"symbol","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"bytes32"}]},
+	{"type":"function","name":"name","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"bytes32"}]}
+]`
+	// ref: https://github.com/Uniswap/uniswap-v2-core/blob/master/contracts/interfaces/IUniswapV2Pair.sol
+	pairABI = `[
+	{"type":"function","name":"getReserves","stateMutability":"view","inputs":[],"outputs":[{"name":"reserve0","type":"uint112"},{"name":"reserve1","type":"uint112"},{"name":"blockTimestampLast","type":"uint32"}]}
+]`
+)
+
+var (
+	// ERC20ABI is the parsed ERC20 metadata ABI
+	ERC20ABI = mustParseABI(erc20ABI)
+	// ERC20Bytes32ABI is the parsed ERC20 metadata ABI of tokens returning bytes32 symbol and name
+	ERC20Bytes32ABI = mustParseABI(erc20Bytes32ABI)
+	// PairABI is the parsed IUniswapV2Pair ABI subset
+	PairABI = mustParseABI(pairABI)
+)
+
+func mustParseABI(s string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(s))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
@@ -0,0 +1,192 @@
+package fetcher
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+	"github.com/miraclesu/uniswap-sdk-go/entities"
+)
+
+var (
+	// ErrInvalidOutput the contract returned an unexpected output
+	ErrInvalidOutput = errors.New("invalid contract output")
+)
+
+// ContractCaller is the minimal interface to read contract state, satisfied by go-ethereum's ethclient.Client,
+// backends.SimulatedBackend or any in-memory fake
+type ContractCaller interface {
+	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+}
+
+// Fetcher loads Token metadata and Pair reserves from a chain
+type Fetcher struct {
+	caller ContractCaller
+
+	lk *sync.RWMutex
+	// chain id : token address : token
+	tokens map[constants.ChainID]map[common.Address]*entities.Token
+}
+
+// New creates a Fetcher, the wrapped native tokens of the registered chains such as entities.WETH are cached
+func New(caller ContractCaller) *Fetcher {
+	chains := entities.Chains()
+	f := &Fetcher{
+		caller: caller,
+		lk:     new(sync.RWMutex),
+		tokens: make(map[constants.ChainID]map[common.Address]*entities.Token, len(chains)),
+	}
+	for _, chain := range chains {
+		if chain.WrappedNative != nil {
+			f.cache(chain.WrappedNative)
+		}
+	}
+	return f
+}
+
+func (f *Fetcher) cache(token *entities.Token) {
+	tokens, ok := f.tokens[token.ChainID]
+	if !ok {
+		tokens = make(map[common.Address]*entities.Token, 1)
+		f.tokens[token.ChainID] = tokens
+	}
+	tokens[token.Address] = token
+}
+
+func (f *Fetcher) cached(chainID constants.ChainID, address common.Address) (*entities.Token, bool) {
+	f.lk.RLock()
+	defer f.lk.RUnlock()
+
+	token, ok := f.tokens[chainID][address]
+	return token, ok
+}
+
+// FetchTokenData fetches information for a given token on the given chain, using the given caller.
+// @param chainID chain of the token
+// @param address address of the token on the chain
+func (f *Fetcher) FetchTokenData(ctx context.Context, chainID constants.ChainID, address common.Address) (*entities.Token, error) {
+	if token, ok := f.cached(chainID, address); ok {
+		return token, nil
+	}
+
+	outputs, err := f.call(ctx, address, ERC20ABI, "decimals")
+	if err != nil {
+		return nil, err
+	}
+	decimals, ok := outputs[0].(uint8)
+	if !ok {
+		return nil, ErrInvalidOutput
+	}
+	symbol, err := f.callString(ctx, address, "symbol")
+	if err != nil {
+		return nil, err
+	}
+	name, err := f.callString(ctx, address, "name")
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := entities.NewToken(chainID, address, int(decimals), symbol, name)
+	if err != nil {
+		return nil, err
+	}
+
+	f.lk.Lock()
+	defer f.lk.Unlock()
+	f.cache(token)
+	return token, nil
+}
+
+// FetchPairData fetches information about a Uniswap V2 pair and constructs a pair from the given two tokens.
+// @param tokenA first token
+// @param tokenB second token
+func (f *Fetcher) FetchPairData(ctx context.Context, tokenA, tokenB *entities.Token) (*entities.Pair, error) {
+	return f.FetchProtocolPairData(ctx, entities.UniswapV2, tokenA, tokenB)
+}
+
+// FetchProtocolPairData fetches information about a pair deployed by the protocol and constructs a pair from the
+// given two tokens.
+// @param protocol the protocol the pair is deployed by
+// @param tokenA first token
+// @param tokenB second token
+func (f *Fetcher) FetchProtocolPairData(ctx context.Context, protocol *entities.Protocol, tokenA, tokenB *entities.Token) (*entities.Pair, error) {
+	address, err := protocol.GetPairAddress(tokenA, tokenB)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs, err := f.call(ctx, address, PairABI, "getReserves")
+	if err != nil {
+		return nil, err
+	}
+	reserve0, ok := outputs[0].(*big.Int)
+	if !ok {
+		return nil, ErrInvalidOutput
+	}
+	reserve1, ok := outputs[1].(*big.Int)
+	if !ok {
+		return nil, ErrInvalidOutput
+	}
+
+	// tokens are on the same chain and have different addresses, which is checked in GetPairAddress
+	if sorted, _ := tokenA.SortsBefore(tokenB); !sorted {
+		reserve0, reserve1 = reserve1, reserve0
+	}
+	tokenAmountA, err := entities.NewTokenAmount(tokenA, reserve0)
+	if err != nil {
+		return nil, err
+	}
+	tokenAmountB, err := entities.NewTokenAmount(tokenB, reserve1)
+	if err != nil {
+		return nil, err
+	}
+	return entities.NewPairWithProtocol(tokenAmountA, tokenAmountB, protocol)
+}
+
+func (f *Fetcher) call(ctx context.Context, address common.Address, contractABI abi.ABI, method string) ([]interface{}, error) {
+	data, err := contractABI.Pack(method)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := f.caller.CallContract(ctx, ethereum.CallMsg{To: &address, Data: data}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return contractABI.Unpack(method, output)
+}
+
+// callString calls a string getter, falling back to the bytes32 variant
+func (f *Fetcher) callString(ctx context.Context, address common.Address, method string) (string, error) {
+	data, err := ERC20ABI.Pack(method)
+	if err != nil {
+		return "", err
+	}
+
+	output, err := f.caller.CallContract(ctx, ethereum.CallMsg{To: &address, Data: data}, nil)
+	if err != nil {
+		return "", err
+	}
+	if outputs, err := ERC20ABI.Unpack(method, output); err == nil {
+		if s, ok := outputs[0].(string); ok {
+			return s, nil
+		}
+	}
+
+	outputs, err := ERC20Bytes32ABI.Unpack(method, output)
+	if err != nil {
+		return "", err
+	}
+	b, ok := outputs[0].([32]byte)
+	if !ok {
+		return "", ErrInvalidOutput
+	}
+	return string(bytes.TrimRight(b[:], "\x00")), nil
+}
@@ -0,0 +1,193 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+	"github.com/miraclesu/uniswap-sdk-go/entities"
+)
+
+var errNoContract = errors.New("no contract")
+
+// fakeCaller is an in-memory ContractCaller
+type fakeCaller struct {
+	// contract address : method selector : output
+	outputs map[common.Address]map[string][]byte
+	calls   int
+}
+
+func newFakeCaller() *fakeCaller {
+	return &fakeCaller{
+		outputs: make(map[common.Address]map[string][]byte),
+	}
+}
+
+func (c *fakeCaller) set(t *testing.T, address common.Address, contractABI abi.ABI, method string, outputs ...interface{}) {
+	output, err := contractABI.Methods[method].Outputs.Pack(outputs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.outputs[address]; !ok {
+		c.outputs[address] = make(map[string][]byte)
+	}
+	c.outputs[address][string(contractABI.Methods[method].ID)] = output
+}
+
+func (c *fakeCaller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	c.calls++
+	output, ok := c.outputs[*call.To][string(call.Data[:4])]
+	if !ok {
+		return nil, errNoContract
+	}
+	return output, nil
+}
+
+func TestFetchTokenData(t *testing.T) {
+	DAI := common.HexToAddress("0x6B175474E89094C44Da98b954EedeAC495271d0F")
+	MKR := common.HexToAddress("0x9f8F72aA9304c8B593d555F12eF6589cC3A579A2")
+
+	caller := newFakeCaller()
+	caller.set(t, DAI, ERC20ABI, "decimals", uint8(18))
+	caller.set(t, DAI, ERC20ABI, "symbol", "DAI")
+	caller.set(t, DAI, ERC20ABI, "name", "Dai Stablecoin")
+	var symbol, name [32]byte
+	copy(symbol[:], "MKR")
+	copy(name[:], "Maker")
+	caller.set(t, MKR, ERC20ABI, "decimals", uint8(18))
+	caller.set(t, MKR, ERC20Bytes32ABI, "symbol", symbol)
+	caller.set(t, MKR, ERC20Bytes32ABI, "name", name)
+	f := New(caller)
+
+	tests := []struct {
+		name    string
+		chainID constants.ChainID
+		address common.Address
+		want    *entities.Token
+		calls   int
+	}{
+		{
+			"token",
+			constants.Mainnet,
+			DAI,
+			&entities.Token{Currency: &entities.Currency{Decimals: 18, Symbol: "DAI", Name: "Dai Stablecoin"}, ChainID: constants.Mainnet, Address: DAI},
+			3,
+		},
+		{
+			"token is cached",
+			constants.Mainnet,
+			DAI,
+			&entities.Token{Currency: &entities.Currency{Decimals: 18, Symbol: "DAI", Name: "Dai Stablecoin"}, ChainID: constants.Mainnet, Address: DAI},
+			0,
+		},
+		{
+			"token with bytes32 symbol and name",
+			constants.Mainnet,
+			MKR,
+			&entities.Token{Currency: &entities.Currency{Decimals: 18, Symbol: "MKR", Name: "Maker"}, ChainID: constants.Mainnet, Address: MKR},
+			3,
+		},
+		{
+			"known token",
+			constants.Rinkeby,
+			entities.WETH[constants.Rinkeby].Address,
+			entities.WETH[constants.Rinkeby],
+			0,
+		},
+	}
+	for _, tt := range tests {
+		caller.calls = 0
+		got, err := f.FetchTokenData(context.Background(), tt.chainID, tt.address)
+		calls := caller.calls
+		want := tt.want
+		t.Run(tt.name, func(t *testing.T) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equals(want) || !got.Currency.Equals(want.Currency) {
+				t.Errorf("FetchTokenData() = %+v, want %+v", got, want)
+			}
+			if calls != tt.calls {
+				t.Errorf("calls = %v, want %v", calls, tt.calls)
+			}
+		})
+	}
+
+	// returns the caller error
+	{
+		_, output := f.FetchTokenData(context.Background(), constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000001"))
+		if output != errNoContract {
+			t.Errorf("expect[%+v], but got[%+v]", errNoContract, output)
+		}
+	}
+}
+
+func TestFetchPairData(t *testing.T) {
+	USDC, _ := entities.NewToken(constants.Mainnet, common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"), 6, "USDC", "USD Coin")
+	DAI, _ := entities.NewToken(constants.Mainnet, common.HexToAddress("0x6B175474E89094C44Da98b954EedeAC495271d0F"), 18, "DAI", "Dai Stablecoin")
+	address, _ := entities.GetPairAddress(USDC, DAI)
+
+	caller := newFakeCaller()
+	// DAI sorts before USDC
+	caller.set(t, address, PairABI, "getReserves", big.NewInt(100), big.NewInt(200), uint32(1))
+	f := New(caller)
+
+	for _, tokens := range [][2]*entities.Token{{USDC, DAI}, {DAI, USDC}} {
+		pair, err := f.FetchPairData(context.Background(), tokens[0], tokens[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pair.GetAddress() != address {
+			t.Errorf("expect[%+v], but got[%+v]", address, pair.GetAddress())
+		}
+		var tests = []struct {
+			token   *entities.Token
+			reserve *big.Int
+		}{
+			{DAI, big.NewInt(100)},
+			{USDC, big.NewInt(200)},
+		}
+		for i, test := range tests {
+			output, err := pair.ReserveOf(test.token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if output.Raw().Cmp(test.reserve) != 0 {
+				t.Errorf("test #%d: expect[%+v], but got[%+v]", i, test.reserve, output.Raw())
+			}
+		}
+	}
+
+	// cannot be used for tokens on different chains
+	{
+		_, output := f.FetchPairData(context.Background(), USDC, entities.WETH[constants.Rinkeby])
+		if output != entities.ErrDiffChainID {
+			t.Errorf("expect[%+v], but got[%+v]", entities.ErrDiffChainID, output)
+		}
+	}
+}
+
+func TestFetchProtocolPairData(t *testing.T) {
+	WBNB, _ := entities.NewToken(constants.ChainID(56), common.HexToAddress("0xbb4CdB9CBd36B01bD1cBaEBF2De08d9173bc095c"), 18, "WBNB", "Wrapped BNB")
+	BUSD, _ := entities.NewToken(constants.ChainID(56), common.HexToAddress("0xe9e7CEA3DedcA5984780Bafc599bD69ADd087D56"), 18, "BUSD", "BUSD Token")
+	address := common.HexToAddress("0x58F876857a02D6762E0101bb5C46A8c1ED44Dc16")
+
+	caller := newFakeCaller()
+	caller.set(t, address, PairABI, "getReserves", big.NewInt(100), big.NewInt(200), uint32(1))
+	pair, err := New(caller).FetchProtocolPairData(context.Background(), entities.PancakeSwapV2, WBNB, BUSD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pair.Protocol != entities.PancakeSwapV2 {
+		t.Errorf("expect[%+v], but got[%+v]", entities.PancakeSwapV2.Name, pair.Protocol.Name)
+	}
+	if pair.GetAddress() != address {
+		t.Errorf("expect[%+v], but got[%+v]", address, pair.GetAddress())
+	}
+}
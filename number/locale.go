@@ -0,0 +1,54 @@
+package number
+
+import (
+	"errors"
+	"strings"
+)
+
+// the spacing between a currency symbol and the number, the no-break space
+const symbolSpacing = "\u00a0"
+
+// Locale a number formatting profile, the separators, group sizes and symbol placements follow CLDR
+type Locale struct {
+	Tag              string
+	DecimalSeparator rune
+	GroupSeparator   rune
+	GroupSize        uint
+	// the size of the groups after the first one, e.g. 2 for the lakh grouping of en-IN, 0 if it is GroupSize
+	SecondaryGroupSize uint
+	// places the currency symbol after the number instead of before it
+	SymbolSuffix bool
+}
+
+var (
+	EnUS = &Locale{Tag: "en-US", DecimalSeparator: '.', GroupSeparator: ',', GroupSize: 3}
+	EnGB = &Locale{Tag: "en-GB", DecimalSeparator: '.', GroupSeparator: ',', GroupSize: 3}
+	EnIN = &Locale{Tag: "en-IN", DecimalSeparator: '.', GroupSeparator: ',', GroupSize: 3, SecondaryGroupSize: 2}
+	DeDE = &Locale{Tag: "de-DE", DecimalSeparator: ',', GroupSeparator: '.', GroupSize: 3, SymbolSuffix: true}
+	// de-CH groups with the right single quotation mark
+	DeCH = &Locale{Tag: "de-CH", DecimalSeparator: '.', GroupSeparator: '\u2019', GroupSize: 3}
+	// fr-FR groups with the narrow no-break space
+	FrFR = &Locale{Tag: "fr-FR", DecimalSeparator: ',', GroupSeparator: '\u202f', GroupSize: 3, SymbolSuffix: true}
+	JaJP = &Locale{Tag: "ja-JP", DecimalSeparator: '.', GroupSeparator: ',', GroupSize: 3}
+
+	// lower case tag : locale
+	locales = map[string]*Locale{}
+
+	// ErrUnknownLocale unknown locale
+	ErrUnknownLocale = errors.New("unknown locale")
+)
+
+func init() {
+	for _, locale := range []*Locale{EnUS, EnGB, EnIN, DeDE, DeCH, FrFR, JaJP} {
+		locales[strings.ToLower(locale.Tag)] = locale
+	}
+}
+
+// LookupLocale returns the built-in locale of the tag, e.g. "en-US", case insensitively and with either '-' or '_'
+func LookupLocale(tag string) (*Locale, error) {
+	locale, ok := locales[strings.ToLower(strings.ReplaceAll(tag, "_", "-"))]
+	if !ok {
+		return nil, ErrUnknownLocale
+	}
+	return locale, nil
+}
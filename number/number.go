@@ -3,6 +3,7 @@ package number
 import (
 	"bytes"
 	"fmt"
+	"math/big"
 	"strings"
 
 	"github.com/shopspring/decimal"
@@ -21,11 +22,7 @@ func New(opt ...Option) *Options {
 // DecimalFormat produces a string form of the given decimal.Decimal in base 10
 //
 // ref: https://github.com/dustin/go-humanize/blob/master/commaf.go#L13
-func DecimalFormat(d decimal.Decimal, opts *Options) (formatted string) {
-	defer func() {
-		formatted = removeNonBreakingSpace(formatted)
-	}()
-
+func DecimalFormat(d decimal.Decimal, opts *Options) string {
 	buf := &bytes.Buffer{}
 	s := d.String()
 	parts := strings.Split(s, ".")
@@ -33,13 +30,21 @@ func DecimalFormat(d decimal.Decimal, opts *Options) (formatted string) {
 		s = d.StringFixed(int32(*opts.decimalPlaces))
 	}
 
-	if d.Sign() < 0 {
+	if strings.HasPrefix(s, "-") {
 		s = s[1:]
 		buf.WriteByte('-')
-		d.Abs()
 	}
+	prefix, suffix := opts.affixes()
+	buf.WriteString(prefix)
+	buf.WriteString(formatNumber(s, opts))
+	buf.WriteString(suffix)
+	return buf.String()
+}
 
-	parts = strings.Split(s, ".")
+// formatNumber formats the absolute value string s with the separators and decimal places of the options
+func formatNumber(s string, opts *Options) string {
+	buf := &bytes.Buffer{}
+	parts := strings.Split(s, ".")
 	buf.WriteString(formatGroup(parts[0], opts.groupSize, opts.secondaryGroupSize, opts.groupSeparator))
 	if len(parts) == 1 && (opts.decimalPlaces == nil || *opts.decimalPlaces == 0) {
 		return buf.String()
@@ -57,13 +62,20 @@ func DecimalFormat(d decimal.Decimal, opts *Options) (formatted string) {
 		parts[1] = parts[1][:*opts.decimalPlaces]
 	}
 
-	buf.WriteByte(opts.decimalSeparator)
+	writeSeparator(buf, opts.decimalSeparator)
 	buf.WriteString(formatFraction(parts[1], opts.fractionGroupSize, opts.fractionGroupSeparator))
 
 	return strings.TrimRight(buf.String(), string(opts.fractionGroupSeparator))
 }
 
-func formatGroup(num string, groupSize, secondaryGroupSize uint, groupSeparator byte) string {
+// writeSeparator writes the separator unless it is NoSeparator
+func writeSeparator(buf *bytes.Buffer, separator rune) {
+	if separator != NoSeparator {
+		buf.WriteRune(separator)
+	}
+}
+
+func formatGroup(num string, groupSize, secondaryGroupSize uint, groupSeparator rune) string {
 	var buf = new(bytes.Buffer)
 	var pos uint = 0
 	iLen := uint(len(num))
@@ -77,12 +89,12 @@ func formatGroup(num string, groupSize, secondaryGroupSize uint, groupSeparator
 			if subPOS := iLen % groupSize; subPOS != 0 {
 				pos += subPOS
 				buf.WriteString(num[:pos])
-				buf.WriteByte(groupSeparator)
+				writeSeparator(buf, groupSeparator)
 			}
 
 			for ; pos < iLen; pos += groupSize {
 				buf.WriteString(num[pos : pos+groupSize])
-				buf.WriteByte(groupSeparator)
+				writeSeparator(buf, groupSeparator)
 			}
 
 			buf.WriteString(num[iLen:])
@@ -94,7 +106,7 @@ func formatGroup(num string, groupSize, secondaryGroupSize uint, groupSeparator
 	return buf.String()
 }
 
-func formatFraction(num string, fractionGroupSize uint, fractionGroupSeparator byte) string {
+func formatFraction(num string, fractionGroupSize uint, fractionGroupSeparator rune) string {
 	var buf = new(bytes.Buffer)
 	var pos uint = 0
 	fLen := uint(len(num))
@@ -106,12 +118,12 @@ func formatFraction(num string, fractionGroupSize uint, fractionGroupSeparator b
 	lastPOS := fLen % fractionGroupSize
 	for ; pos < fLen-lastPOS; pos += fractionGroupSize {
 		buf.WriteString(num[pos : pos+fractionGroupSize])
-		buf.WriteByte(fractionGroupSeparator)
+		writeSeparator(buf, fractionGroupSeparator)
 	}
 
 	if lastPOS > 0 {
 		buf.WriteString(num[pos : pos+lastPOS])
-		buf.WriteByte(fractionGroupSeparator)
+		writeSeparator(buf, fractionGroupSeparator)
 	}
 
 	return buf.String()
@@ -125,5 +137,17 @@ func DecimalRound(d decimal.Decimal, opts *Options) (decimal.Decimal, error) {
 		return decimal.Decimal{}, ErrInvalidRM
 	}
 
-	return modeHandles[opts.mode](d, opts.prec)
+	return modeHandles[opts.mode](d.Rat(), opts.prec)
+}
+
+// RatRound returns r rounded to the given precision using the given rounding mode.
+//
+// The exact value of r is rounded, so a quotient is rounded once instead of being truncated to a decimal.Decimal first,
+// r is not modified.
+func RatRound(r *big.Rat, opts *Options) (decimal.Decimal, error) {
+	if !opts.mode.Valid() {
+		return decimal.Decimal{}, ErrInvalidRM
+	}
+
+	return modeHandles[opts.mode](new(big.Rat).Set(r), opts.prec)
 }
@@ -1,6 +1,7 @@
 package number
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/shopspring/decimal"
@@ -739,10 +740,24 @@ func TestDecimalFormat(t *testing.T) {
 		{
 			args: args{
 				d:    mustNewFromString("10000000000123456789000000.000000000100000001"),
-				opts: New(WithSecondaryGroupSize(2), WithDecimalSeparator(','), WithGroupSeparator('\xA0'), WithDecimalPlaces(10)),
+				opts: New(WithSecondaryGroupSize(2), WithDecimalSeparator(','), WithGroupSeparator(NoSeparator), WithDecimalPlaces(10)),
 			},
 			want: "10000000000123456789000000,0000000001",
 		},
+		{
+			args: args{
+				d:    mustNewFromString("1234567.891"),
+				opts: New(WithDecimalSeparator(','), WithGroupSeparator('\u00a0'), WithDecimalPlaces(2)),
+			},
+			want: "1\u00a0234\u00a0567,89",
+		},
+		{
+			args: args{
+				d:    mustNewFromString("1234567.891234"),
+				opts: New(WithFractionGroupSeparator('\u00a0'), WithFractionGroupSize(3)),
+			},
+			want: "1,234,567.891\u00a0234",
+		},
 	}
 
 	for i, tt := range tests {
@@ -1748,3 +1763,133 @@ func TestDecimalRound(t *testing.T) {
 		}
 	}
 }
+
+// the expected values are generated with the quantize of Python's decimal module at 200 digits of precision, whose
+// ROUND_* modes are the rounding modes of decimal.js, in the order of modes, negative zeros are written as 0
+func TestDecimalRoundModes(t *testing.T) {
+	modes := []constants.Rounding{
+		constants.RoundDown,
+		constants.RoundHalfUp,
+		constants.RoundUp,
+		constants.RoundHalfEven,
+		constants.RoundHalfDown,
+		constants.RoundCeiling,
+		constants.RoundFloor,
+	}
+	tests := []struct {
+		d    string
+		prec int
+		want [7]string
+	}{
+		{"2.5", 0, [7]string{"2", "3", "3", "2", "2", "3", "2"}},
+		{"-2.5", 0, [7]string{"-2", "-3", "-3", "-2", "-2", "-2", "-3"}},
+		{"3.5", 0, [7]string{"3", "4", "4", "4", "3", "4", "3"}},
+		{"-3.5", 0, [7]string{"-3", "-4", "-4", "-4", "-3", "-3", "-4"}},
+		{"0.5", 0, [7]string{"0", "1", "1", "0", "0", "1", "0"}},
+		{"-0.5", 0, [7]string{"0", "-1", "-1", "0", "0", "0", "-1"}},
+		{"-0.4", 0, [7]string{"0", "0", "-1", "0", "0", "0", "-1"}},
+		{"1.25", 1, [7]string{"1.2", "1.3", "1.3", "1.2", "1.2", "1.3", "1.2"}},
+		{"-1.25", 1, [7]string{"-1.2", "-1.3", "-1.3", "-1.2", "-1.2", "-1.2", "-1.3"}},
+		{"-1.35", 1, [7]string{"-1.3", "-1.4", "-1.4", "-1.4", "-1.3", "-1.3", "-1.4"}},
+		{"1.005", 2, [7]string{"1.00", "1.01", "1.01", "1.00", "1.00", "1.01", "1.00"}},
+		{"-1.005", 2, [7]string{"-1.00", "-1.01", "-1.01", "-1.00", "-1.00", "-1.00", "-1.01"}},
+		{"-0.125", 2, [7]string{"-0.12", "-0.13", "-0.13", "-0.12", "-0.12", "-0.12", "-0.13"}},
+		{"-0.000015", 5, [7]string{"-0.00001", "-0.00002", "-0.00002", "-0.00002", "-0.00001", "-0.00001", "-0.00002"}},
+		{"1234.5678", 3, [7]string{"1234.567", "1234.568", "1234.568", "1234.568", "1234.568", "1234.568", "1234.567"}},
+		{"7", 1, [7]string{"7.0", "7.0", "7.0", "7.0", "7.0", "7.0", "7.0"}},
+		{"2.00000000000000001", 0, [7]string{"2", "2", "3", "2", "2", "3", "2"}},
+		{"-2.00000000000000001", 0, [7]string{"-2", "-2", "-3", "-2", "-2", "-2", "-3"}},
+		{"2.50000000000000000001", 0, [7]string{"2", "3", "3", "3", "3", "3", "2"}},
+		{"-2.49999999999999999999", 0, [7]string{"-2", "-2", "-3", "-2", "-2", "-2", "-3"}},
+		{"0.12345678901234567895", 19, [7]string{"0.1234567890123456789", "0.1234567890123456790", "0.1234567890123456790", "0.1234567890123456790", "0.1234567890123456789", "0.1234567890123456790", "0.1234567890123456789"}},
+		{"-0.12345678901234567895", 19, [7]string{"-0.1234567890123456789", "-0.1234567890123456790", "-0.1234567890123456790", "-0.1234567890123456790", "-0.1234567890123456789", "-0.1234567890123456789", "-0.1234567890123456790"}},
+		{"-0.12345678901234567885", 19, [7]string{"-0.1234567890123456788", "-0.1234567890123456789", "-0.1234567890123456789", "-0.1234567890123456788", "-0.1234567890123456788", "-0.1234567890123456788", "-0.1234567890123456789"}},
+		{"123456789012345678901234.5", 0, [7]string{"123456789012345678901234", "123456789012345678901235", "123456789012345678901235", "123456789012345678901234", "123456789012345678901234", "123456789012345678901235", "123456789012345678901234"}},
+		{"-123456789012345678901234.5", 0, [7]string{"-123456789012345678901234", "-123456789012345678901235", "-123456789012345678901235", "-123456789012345678901234", "-123456789012345678901234", "-123456789012345678901234", "-123456789012345678901235"}},
+		{"0.000000000000000000000000015", 26, [7]string{"0.00000000000000000000000001", "0.00000000000000000000000002", "0.00000000000000000000000002", "0.00000000000000000000000002", "0.00000000000000000000000001", "0.00000000000000000000000002", "0.00000000000000000000000001"}},
+		{"-0.000000000000000000000000025", 26, [7]string{"-0.00000000000000000000000002", "-0.00000000000000000000000003", "-0.00000000000000000000000003", "-0.00000000000000000000000002", "-0.00000000000000000000000002", "-0.00000000000000000000000002", "-0.00000000000000000000000003"}},
+	}
+	for _, tt := range tests {
+		for i, mode := range modes {
+			opts := New(WithRoundingPrecision(tt.prec), WithRoundingMode(mode))
+			want := mustNewFromString(tt.want[i])
+			got, err := DecimalRound(mustNewFromString(tt.d), opts)
+			if err != nil {
+				t.Fatalf("DecimalRound(%s, %d, %d) error = %v", tt.d, tt.prec, mode, err)
+			}
+			if !got.Equal(want) {
+				t.Errorf("DecimalRound(%s, %d, %d) got = %v, want %v", tt.d, tt.prec, mode, got, want)
+			}
+			r := mustNewFromString(tt.d).Rat()
+			if got, err = RatRound(r, opts); err != nil {
+				t.Fatalf("RatRound(%s, %d, %d) error = %v", tt.d, tt.prec, mode, err)
+			}
+			if !got.Equal(want) {
+				t.Errorf("RatRound(%s, %d, %d) got = %v, want %v", tt.d, tt.prec, mode, got, want)
+			}
+			if r.Cmp(mustNewFromString(tt.d).Rat()) != 0 {
+				t.Errorf("RatRound(%s, %d, %d) should not modify r", tt.d, tt.prec, mode)
+			}
+		}
+	}
+
+	if _, err := DecimalRound(mustNewFromString("1"), New(WithRoundingMode(constants.RoundFloor+1))); err != ErrInvalidRM {
+		t.Errorf("DecimalRound() error = %v, want %v", err, ErrInvalidRM)
+	}
+	if _, err := RatRound(big.NewRat(1, 1), New(WithRoundingMode(constants.RoundFloor+1))); err != ErrInvalidRM {
+		t.Errorf("RatRound() error = %v, want %v", err, ErrInvalidRM)
+	}
+}
+
+func TestDecimalFormatLocales(t *testing.T) {
+	d := mustNewFromString("1234567.891")
+	negative := mustNewFromString("-1234.5")
+	tests := []struct {
+		d    decimal.Decimal
+		opts *Options
+		want string
+	}{
+		{d, New(WithLocale(EnUS), WithDecimalPlaces(2)), "1,234,567.89"},
+		{d, New(WithLocale(EnGB), WithDecimalPlaces(2)), "1,234,567.89"},
+		{d, New(WithLocale(DeDE), WithDecimalPlaces(2)), "1.234.567,89"},
+		{d, New(WithLocale(DeCH), WithDecimalPlaces(2)), "1’234’567.89"},
+		{d, New(WithLocale(FrFR), WithDecimalPlaces(2)), "1\u202f234\u202f567,89"},
+		{d, New(WithLocale(EnIN), WithDecimalPlaces(2)), "12,34,567.89"},
+		{d, New(WithLocale(JaJP), WithDecimalPlaces(0)), "1,234,568"},
+		{d, New(WithLocale(EnUS), WithGroupSeparator(NoSeparator)), "1234567.891"},
+		{d, New(WithGroupSeparator('_'), WithDecimalSeparator('٫')), "1_234_567٫891"},
+		{d, New(WithFractionGroupSeparator(' '), WithFractionGroupSize(2)), "1,234,567.89 1"},
+
+		{negative, New(WithLocale(EnUS), WithSymbol("$"), WithDecimalPlaces(2)), "-$1,234.50"},
+		{negative, New(WithLocale(EnUS), WithSymbol("USD"), WithDecimalPlaces(2)), "-USD\u00a01,234.50"},
+		{negative, New(WithLocale(DeDE), WithSymbol("€"), WithDecimalPlaces(2)), "-1.234,50\u00a0€"},
+		{negative, New(WithLocale(FrFR), WithSymbol("€"), WithDecimalPlaces(2)), "-1\u202f234,50\u00a0€"},
+		{negative, New(WithLocale(EnIN), WithSymbol("₹")), "-₹1,234.5"},
+		{negative, New(WithLocale(JaJP), WithSymbol("¥"), WithDecimalPlaces(0)), "-¥1,235"},
+		{negative, New(WithSymbol("ETH")), "-1,234.5 ETH"},
+		{negative, New(WithPrefix("~"), WithSuffix(" per block")), "-~1,234.5 per block"},
+		{negative, New(WithLocale(EnUS), WithPrefix("~"), WithSymbol("$")), "-~$1,234.5"},
+	}
+	for i, tt := range tests {
+		if got := DecimalFormat(tt.d, tt.opts); got != tt.want {
+			t.Errorf("DecimalFormat([%d]{d:[%+v]}) got = %q, want %q", i, tt.d.String(), got, tt.want)
+		}
+	}
+}
+
+func TestLookupLocale(t *testing.T) {
+	for tag, want := range map[string]*Locale{
+		"en-US": EnUS,
+		"de_DE": DeDE,
+		"fr-fr": FrFR,
+		"EN-IN": EnIN,
+		"ja-JP": JaJP,
+	} {
+		if got, err := LookupLocale(tag); err != nil || got != want {
+			t.Errorf("LookupLocale(%s) got = %+v, %v, want %+v", tag, got, err, want)
+		}
+	}
+	if _, err := LookupLocale("xx-XX"); err != ErrUnknownLocale {
+		t.Errorf("LookupLocale() error = %v, want %v", err, ErrUnknownLocale)
+	}
+}
@@ -1,11 +1,16 @@
 package number
 
 import (
-	"bytes"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/miraclesu/uniswap-sdk-go/constants"
 )
 
+// NoSeparator the separator which is not written, e.g. to format numbers without grouping, the NUL rune is never a
+// separator so that any printable rune, including the no-break space, can be used as one
+const NoSeparator rune = 0
+
 type (
 	Options struct {
 		formatOptions
@@ -13,13 +18,18 @@ type (
 	}
 
 	formatOptions struct {
-		decimalSeparator       byte
-		groupSeparator         byte
+		decimalSeparator       rune
+		groupSeparator         rune
 		groupSize              uint
 		secondaryGroupSize     uint
-		fractionGroupSeparator byte
+		fractionGroupSeparator rune
 		fractionGroupSize      uint
 		decimalPlaces          *uint
+		// the locale placing the symbol, nil to place it as a suffix separated by a space
+		locale *Locale
+		symbol string
+		prefix string
+		suffix string
 	}
 
 	roundingOptions struct {
@@ -39,7 +49,7 @@ var (
 		groupSeparator:         ',',
 		groupSize:              3,
 		secondaryGroupSize:     0,
-		fractionGroupSeparator: '\xA0',
+		fractionGroupSeparator: NoSeparator,
 		fractionGroupSize:      0,
 		decimalPlaces:          nil,
 	}
@@ -74,18 +84,70 @@ func newFuncOption(f func(*Options)) *funcOption {
 	}
 }
 
-func WithDecimalSeparator(decimalSeparator byte) Option {
+func WithDecimalSeparator(decimalSeparator rune) Option {
 	return newFuncOption(func(o *Options) {
 		o.decimalSeparator = decimalSeparator
 	})
 }
 
-func WithGroupSeparator(groupSeparator byte) Option {
+func WithGroupSeparator(groupSeparator rune) Option {
 	return newFuncOption(func(o *Options) {
 		o.groupSeparator = groupSeparator
 	})
 }
 
+// WithLocale sets the separators and group sizes of the locale, and places the symbol as the locale does
+func WithLocale(locale *Locale) Option {
+	return newFuncOption(func(o *Options) {
+		o.decimalSeparator = locale.DecimalSeparator
+		o.groupSeparator = locale.GroupSeparator
+		o.groupSize = locale.GroupSize
+		o.secondaryGroupSize = locale.SecondaryGroupSize
+		o.locale = locale
+	})
+}
+
+// WithSymbol renders the currency symbol before or after the number as the locale places it, or after the number
+// separated by a space without a locale
+func WithSymbol(symbol string) Option {
+	return newFuncOption(func(o *Options) {
+		o.symbol = symbol
+	})
+}
+
+// WithPrefix renders the prefix before the number, after the minus sign
+func WithPrefix(prefix string) Option {
+	return newFuncOption(func(o *Options) {
+		o.prefix = prefix
+	})
+}
+
+// WithSuffix renders the suffix after the number
+func WithSuffix(suffix string) Option {
+	return newFuncOption(func(o *Options) {
+		o.suffix = suffix
+	})
+}
+
+// affixes returns the strings before and after the number, including the symbol placed as the locale places it
+func (o *Options) affixes() (prefix, suffix string) {
+	prefix, suffix = o.prefix, o.suffix
+	switch {
+	case o.symbol == "":
+	case o.locale == nil:
+		suffix = " " + o.symbol + suffix
+	case o.locale.SymbolSuffix:
+		suffix = symbolSpacing + o.symbol + suffix
+	default:
+		prefix += o.symbol
+		// separate the letters of a symbol such as USD from the digits, but not a sign such as $
+		if r, _ := utf8.DecodeLastRuneInString(o.symbol); unicode.IsLetter(r) {
+			prefix += symbolSpacing
+		}
+	}
+	return prefix, suffix
+}
+
 func WithGroupSize(groupSize uint) Option {
 	return newFuncOption(func(o *Options) {
 		o.groupSize = groupSize
@@ -98,7 +160,7 @@ func WithSecondaryGroupSize(secondaryGroupSize uint) Option {
 	})
 }
 
-func WithFractionGroupSeparator(fractionGroupSeparator byte) Option {
+func WithFractionGroupSeparator(fractionGroupSeparator rune) Option {
 	return newFuncOption(func(o *Options) {
 		o.fractionGroupSeparator = fractionGroupSeparator
 	})
@@ -127,8 +189,3 @@ func WithRoundingPrecision(precision int) Option {
 		o.prec = precision
 	})
 }
-
-// remove byte '\xA0'
-func removeNonBreakingSpace(s string) string {
-	return string(bytes.ReplaceAll([]byte(s), []byte{'\xA0'}, []byte("")))
-}
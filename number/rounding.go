@@ -2,6 +2,7 @@ package number
 
 import (
 	"errors"
+	"math/big"
 
 	"github.com/shopspring/decimal"
 	gorounding "github.com/wadey/go-rounding"
@@ -9,31 +10,52 @@ import (
 	"github.com/miraclesu/uniswap-sdk-go/constants"
 )
 
-type modeHandler func(decimal.Decimal, int) (decimal.Decimal, error)
+type modeHandler func(*big.Rat, int) (decimal.Decimal, error)
 
 var (
 	modeHandles = map[constants.Rounding]modeHandler{
-		constants.RoundDown:   roundDownHandle,
-		constants.RoundHalfUp: roundHalfUpHandle,
-		constants.RoundUp:     roundUpHandle,
+		constants.RoundDown:     roundDownHandle,
+		constants.RoundHalfUp:   roundHalfUpHandle,
+		constants.RoundUp:       roundUpHandle,
+		constants.RoundHalfEven: roundHalfEvenHandle,
+		constants.RoundHalfDown: roundHalfDownHandle,
+		constants.RoundCeiling:  roundCeilingHandle,
+		constants.RoundFloor:    roundFloorHandle,
 	}
 
 	// ErrInvalidRM invalid rounding mode
 	ErrInvalidRM = errors.New("invalid rounding mode")
 )
 
-func roundDownHandle(d decimal.Decimal, prec int) (decimal.Decimal, error) {
-	return round(d, prec, gorounding.Down)
+func roundDownHandle(r *big.Rat, prec int) (decimal.Decimal, error) {
+	return round(r, prec, gorounding.Down)
 }
 
-func roundHalfUpHandle(d decimal.Decimal, prec int) (decimal.Decimal, error) {
-	return round(d, prec, gorounding.HalfUp)
+func roundHalfUpHandle(r *big.Rat, prec int) (decimal.Decimal, error) {
+	return round(r, prec, gorounding.HalfUp)
 }
 
-func roundUpHandle(d decimal.Decimal, prec int) (decimal.Decimal, error) {
-	return round(d, prec, gorounding.Up)
+func roundUpHandle(r *big.Rat, prec int) (decimal.Decimal, error) {
+	return round(r, prec, gorounding.Up)
 }
 
-func round(d decimal.Decimal, prec int, mode gorounding.RoundingMode) (decimal.Decimal, error) {
-	return decimal.NewFromString(gorounding.Round(d.Rat(), prec, mode).FloatString(prec))
+func roundHalfEvenHandle(r *big.Rat, prec int) (decimal.Decimal, error) {
+	return round(r, prec, gorounding.HalfEven)
+}
+
+func roundHalfDownHandle(r *big.Rat, prec int) (decimal.Decimal, error) {
+	return round(r, prec, gorounding.HalfDown)
+}
+
+func roundCeilingHandle(r *big.Rat, prec int) (decimal.Decimal, error) {
+	return round(r, prec, gorounding.Ceil)
+}
+
+func roundFloorHandle(r *big.Rat, prec int) (decimal.Decimal, error) {
+	return round(r, prec, gorounding.Floor)
+}
+
+// round rounds r in place
+func round(r *big.Rat, prec int, mode gorounding.RoundingMode) (decimal.Decimal, error) {
+	return decimal.NewFromString(gorounding.Round(r, prec, mode).FloatString(prec))
 }
@@ -0,0 +1,248 @@
+package router
+
+import (
+	"math/big"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+	"github.com/miraclesu/uniswap-sdk-go/entities"
+)
+
+// AddLiquidityParameters the parameters to use in the call to the Uniswap V2 Router to add liquidity.
+type AddLiquidityParameters struct {
+	*SwapParameters
+	// the amounts deposited at the current reserves, one of the desired amounts and the amount quoted from it.
+	AmountA *entities.TokenAmount
+	AmountB *entities.TokenAmount
+	// the minimum amounts deposited for the allowed slippage.
+	AmountAMin *entities.TokenAmount
+	AmountBMin *entities.TokenAmount
+}
+
+// quote returns the amount of the other token with the same value at the reserves, same as UniswapV2Library.quote
+func quote(amountA, reserveA, reserveB *big.Int) *big.Int {
+	amountB := big.NewInt(0).Mul(amountA, reserveB)
+	return amountB.Div(amountB, reserveA)
+}
+
+// minimumAmount returns the amount decreased by the slippage tolerance
+func minimumAmount(amount *entities.TokenAmount, slippageTolerance *entities.Percent) (*entities.TokenAmount, error) {
+	if slippageTolerance.LessThan(entities.ZeroFraction) || entities.NewFraction(constants.One, nil).LessThan(slippageTolerance.Fraction) {
+		return nil, entities.ErrInvalidSlippageTolerance
+	}
+
+	minimum := entities.NewFraction(constants.One, nil).
+		Subtract(slippageTolerance.Fraction).
+		Multiply(entities.NewFraction(amount.Raw(), nil)).Quotient()
+	return entities.NewTokenAmount(amount.Token, minimum)
+}
+
+// AddLiquidityCallParameters produces the on-chain method name to call and the hex encoded parameters to pass as
+// arguments for adding liquidity to a pair, addLiquidityETH is used if either amount is of the native
+// currency, e.g. ETHER.
+// The deposited amounts are computed from the desired amounts at the current reserves as the router does.
+// @param pair the pair to add liquidity to
+// @param desiredA the desired amount of one token of the pair
+// @param desiredB the desired amount of the other token of the pair
+// @param options options for the call parameters, FeeOnTransfer is ignored
+// nolint gocyclo
+func AddLiquidityCallParameters(pair *entities.Pair, desiredA, desiredB *entities.TokenAmount,
+	options *TradeOptions) (*AddLiquidityParameters, error) {
+	if !pair.InvolvesToken(desiredA.Token) || !pair.InvolvesToken(desiredB.Token) || desiredA.Token.Equals(desiredB.Token) {
+		return nil, entities.ErrDiffToken
+	}
+	etherA := desiredA.Token.Currency.IsNative()
+	etherB := desiredB.Token.Currency.IsNative()
+	if etherA && etherB {
+		return nil, ErrEtherInOut
+	}
+
+	deadline, err := options.deadline()
+	if err != nil {
+		return nil, err
+	}
+
+	amountA, amountB := desiredA, desiredB
+	reserveA, err := pair.ReserveOf(desiredA.Token)
+	if err != nil {
+		return nil, err
+	}
+	reserveB, err := pair.ReserveOf(desiredB.Token)
+	if err != nil {
+		return nil, err
+	}
+	if reserveA.Raw().Sign() > 0 && reserveB.Raw().Sign() > 0 {
+		if optimalB := quote(desiredA.Raw(), reserveA.Raw(), reserveB.Raw()); optimalB.Cmp(desiredB.Raw()) <= 0 {
+			amountB, err = entities.NewTokenAmount(desiredB.Token, optimalB)
+		} else {
+			amountA, err = entities.NewTokenAmount(desiredA.Token, quote(desiredB.Raw(), reserveB.Raw(), reserveA.Raw()))
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	amountAMin, err := minimumAmount(amountA, options.AllowedSlippage)
+	if err != nil {
+		return nil, err
+	}
+	amountBMin, err := minimumAmount(amountB, options.AllowedSlippage)
+	if err != nil {
+		return nil, err
+	}
+
+	to := options.Recipient
+	var params *SwapParameters
+	switch {
+	case etherA:
+		// (address token, uint amountTokenDesired, uint amountTokenMin, uint amountETHMin, address to, uint deadline)
+		params, err = newSwapParameters("addLiquidityETH", amountA.Raw(),
+			amountB.Token.Address, amountB.Raw(), amountBMin.Raw(), amountAMin.Raw(), to, deadline)
+	case etherB:
+		// (address token, uint amountTokenDesired, uint amountTokenMin, uint amountETHMin, address to, uint deadline)
+		params, err = newSwapParameters("addLiquidityETH", amountB.Raw(),
+			amountA.Token.Address, amountA.Raw(), amountAMin.Raw(), amountBMin.Raw(), to, deadline)
+	default:
+		// (address tokenA, address tokenB, uint amountADesired, uint amountBDesired, uint amountAMin, uint amountBMin,
+		// address to, uint deadline)
+		params, err = newSwapParameters("addLiquidity", big.NewInt(0),
+			amountA.Token.Address, amountB.Token.Address, amountA.Raw(), amountB.Raw(), amountAMin.Raw(), amountBMin.Raw(),
+			to, deadline)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &AddLiquidityParameters{
+		SwapParameters: params,
+		AmountA:        amountA,
+		AmountB:        amountB,
+		AmountAMin:     amountAMin,
+		AmountBMin:     amountBMin,
+	}, nil
+}
+
+// Permit the signed EIP-2612 permit of the liquidity token approving the router to spend the liquidity,
+// its deadline must be the deadline of the call.
+type Permit struct {
+	// whether the permit approves the max uint256 instead of the liquidity.
+	ApproveMax bool
+	V          uint8
+	R          [32]byte
+	S          [32]byte
+}
+
+// RemoveLiquidityOptions options for producing the arguments to remove liquidity.
+type RemoveLiquidityOptions struct {
+	*TradeOptions
+	// whether the protocol fee is on, and the kLast of the pair if so, used to compute the value of the liquidity.
+	FeeOn bool
+	KLast *big.Int
+	// the permit of the liquidity token, the WithPermit methods are used if set.
+	Permit *Permit
+}
+
+// RemoveLiquidityParameters the parameters to use in the call to the Uniswap V2 Router to remove liquidity.
+type RemoveLiquidityParameters struct {
+	*SwapParameters
+	// the amounts withdrawn at the current reserves.
+	AmountA *entities.TokenAmount
+	AmountB *entities.TokenAmount
+	// the minimum amounts withdrawn for the allowed slippage.
+	AmountAMin *entities.TokenAmount
+	AmountBMin *entities.TokenAmount
+}
+
+// RemoveLiquidityCallParameters produces the on-chain method name to call and the hex encoded parameters to pass as
+// arguments for removing liquidity from a pair, the ETH methods are used if either token is of the
+// native currency, e.g. ETHER.
+// The withdrawn amounts are the values of the liquidity computed by Pair.GetLiquidityValue.
+// @param pair the pair to remove liquidity from
+// @param tokenA one token of the pair
+// @param tokenB the other token of the pair
+// @param liquidity the amount of the liquidity token to burn
+// @param totalSupply the total supply of the liquidity token
+// @param options options for the call parameters, FeeOnTransfer is only supported with ETH and implied if the
+// token of the pair declares transfer fees
+// nolint gocyclo
+func RemoveLiquidityCallParameters(pair *entities.Pair, tokenA, tokenB *entities.Token,
+	liquidity, totalSupply *entities.TokenAmount, options *RemoveLiquidityOptions) (*RemoveLiquidityParameters, error) {
+	if tokenA.Equals(tokenB) {
+		return nil, entities.ErrDiffToken
+	}
+	etherA := tokenA.Currency.IsNative()
+	etherB := tokenB.Currency.IsNative()
+	if etherA && etherB {
+		return nil, ErrEtherInOut
+	}
+	// the router transfers the token received from the pair when removing liquidity with ETH
+	feeOnTransfer := options.FeeOnTransfer ||
+		((etherA || etherB) && (pair.Token0().HasTransferFees() || pair.Token1().HasTransferFees()))
+	if feeOnTransfer && !etherA && !etherB {
+		return nil, ErrRemoveFeeOnTransfer
+	}
+
+	deadline, err := options.deadline()
+	if err != nil {
+		return nil, err
+	}
+
+	amountA, err := pair.GetLiquidityValue(tokenA, totalSupply, liquidity, options.FeeOn, options.KLast)
+	if err != nil {
+		return nil, err
+	}
+	amountB, err := pair.GetLiquidityValue(tokenB, totalSupply, liquidity, options.FeeOn, options.KLast)
+	if err != nil {
+		return nil, err
+	}
+	amountAMin, err := minimumAmount(amountA, options.AllowedSlippage)
+	if err != nil {
+		return nil, err
+	}
+	amountBMin, err := minimumAmount(amountB, options.AllowedSlippage)
+	if err != nil {
+		return nil, err
+	}
+
+	var methodName string
+	var args []interface{}
+	to := options.Recipient
+	switch {
+	case etherA || etherB:
+		token, amountTokenMin, amountETHMin := tokenB.Address, amountBMin.Raw(), amountAMin.Raw()
+		if etherB {
+			token, amountTokenMin, amountETHMin = tokenA.Address, amountAMin.Raw(), amountBMin.Raw()
+		}
+		methodName = "removeLiquidityETH"
+		if options.Permit != nil {
+			methodName += "WithPermit"
+		}
+		if feeOnTransfer {
+			methodName += "SupportingFeeOnTransferTokens"
+		}
+		// (address token, uint liquidity, uint amountTokenMin, uint amountETHMin, address to, uint deadline)
+		args = []interface{}{token, liquidity.Raw(), amountTokenMin, amountETHMin, to, deadline}
+	default:
+		methodName = "removeLiquidity"
+		if options.Permit != nil {
+			methodName += "WithPermit"
+		}
+		// (address tokenA, address tokenB, uint liquidity, uint amountAMin, uint amountBMin, address to, uint deadline)
+		args = []interface{}{tokenA.Address, tokenB.Address, liquidity.Raw(), amountAMin.Raw(), amountBMin.Raw(), to, deadline}
+	}
+	if permit := options.Permit; permit != nil {
+		// (..., bool approveMax, uint8 v, bytes32 r, bytes32 s)
+		args = append(args, permit.ApproveMax, permit.V, permit.R, permit.S)
+	}
+
+	params, err := newSwapParameters(methodName, big.NewInt(0), args...)
+	if err != nil {
+		return nil, err
+	}
+	return &RemoveLiquidityParameters{
+		SwapParameters: params,
+		AmountA:        amountA,
+		AmountB:        amountB,
+		AmountAMin:     amountAMin,
+		AmountBMin:     amountBMin,
+	}, nil
+}
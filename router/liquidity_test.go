@@ -0,0 +1,254 @@
+package router
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+	"github.com/miraclesu/uniswap-sdk-go/entities"
+)
+
+func assertCall(t *testing.T, result *SwapParameters, methodName string, value *big.Int, args ...interface{}) {
+	if result.MethodName != methodName {
+		t.Errorf("MethodName = %v, want %v", result.MethodName, methodName)
+	}
+	if result.Value.Cmp(value) != 0 {
+		t.Errorf("Value = %v, want %v", result.Value, value)
+	}
+	if len(result.Args) != len(args) {
+		t.Fatalf("Args = %v, want %v", result.Args, args)
+	}
+	method := ABI.Methods[methodName]
+	if !bytes.Equal(result.Calldata[:4], method.ID) {
+		t.Errorf("Calldata selector = %x, want %x", result.Calldata[:4], method.ID)
+	}
+	unpacked, err := method.Inputs.Unpack(result.Calldata[4:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range args {
+		if !argEqual(result.Args[i], args[i]) {
+			t.Errorf("Args[%d] = %v, want %v", i, result.Args[i], args[i])
+		}
+		if !argEqual(unpacked[i], args[i]) {
+			t.Errorf("Calldata arg %d = %v, want %v", i, unpacked[i], args[i])
+		}
+	}
+}
+
+// nolint funlen
+func TestAddLiquidityCallParameters(t *testing.T) {
+	token0, _ := entities.NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000001"), 18, "t0", "")
+	token1, _ := entities.NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000002"), 18, "t1", "")
+	token2, _ := entities.NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000003"), 18, "t2", "")
+	weth := entities.WETH[constants.Mainnet]
+	ether := entities.NewETHRToken(constants.Mainnet, weth.Address)
+
+	tokenAmount_0_1000, _ := entities.NewTokenAmount(token0, big.NewInt(1000))
+	tokenAmount_1_2000, _ := entities.NewTokenAmount(token1, big.NewInt(2000))
+	tokenAmount_weth_1000, _ := entities.NewTokenAmount(weth, big.NewInt(1000))
+	pair_0_1, _ := entities.NewPair(tokenAmount_0_1000, tokenAmount_1_2000)
+	pair_weth_0, _ := entities.NewPair(tokenAmount_weth_1000, tokenAmount_0_1000)
+
+	recipient := common.HexToAddress("0x0000000000000000000000000000000000000004")
+	deadline := int64(1700000000)
+	options := &TradeOptions{
+		AllowedSlippage: entities.NewPercent(big.NewInt(1), big.NewInt(100)),
+		Deadline:        deadline,
+		Recipient:       recipient,
+	}
+	amount := func(token *entities.Token, raw int64) *entities.TokenAmount {
+		tokenAmount, err := entities.NewTokenAmount(token, big.NewInt(raw))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tokenAmount
+	}
+
+	// quotes amount b from amount a
+	{
+		result, err := AddLiquidityCallParameters(pair_0_1, amount(token0, 100), amount(token1, 300), options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCall(t, result.SwapParameters, "addLiquidity", big.NewInt(0),
+			token0.Address, token1.Address, big.NewInt(100), big.NewInt(200), big.NewInt(99), big.NewInt(198),
+			recipient, big.NewInt(deadline))
+		if result.AmountB.Raw().Int64() != 200 || result.AmountBMin.Raw().Int64() != 198 {
+			t.Errorf("expect[200 198], but got[%+v %+v]", result.AmountB.Raw(), result.AmountBMin.Raw())
+		}
+	}
+
+	// quotes amount a from amount b, keeping the order of the arguments
+	{
+		result, err := AddLiquidityCallParameters(pair_0_1, amount(token1, 150), amount(token0, 100), options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCall(t, result.SwapParameters, "addLiquidity", big.NewInt(0),
+			token1.Address, token0.Address, big.NewInt(150), big.NewInt(75), big.NewInt(148), big.NewInt(74),
+			recipient, big.NewInt(deadline))
+	}
+
+	// adds liquidity with ether
+	{
+		result, err := AddLiquidityCallParameters(pair_weth_0, amount(ether, 100), amount(token0, 50), options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCall(t, result.SwapParameters, "addLiquidityETH", big.NewInt(50),
+			token0.Address, big.NewInt(50), big.NewInt(49), big.NewInt(49), recipient, big.NewInt(deadline))
+	}
+
+	// uses the desired amounts for an empty pair
+	{
+		zero_0, _ := entities.NewTokenAmount(token0, big.NewInt(0))
+		zero_1, _ := entities.NewTokenAmount(token1, big.NewInt(0))
+		empty, _ := entities.NewPair(zero_0, zero_1)
+		result, err := AddLiquidityCallParameters(empty, amount(token0, 100), amount(token1, 300), options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.AmountA.Raw().Int64() != 100 || result.AmountB.Raw().Int64() != 300 {
+			t.Errorf("expect[100 300], but got[%+v %+v]", result.AmountA.Raw(), result.AmountB.Raw())
+		}
+	}
+
+	// errors
+	{
+		_, output := AddLiquidityCallParameters(pair_0_1, amount(token0, 100), amount(token2, 100), options)
+		if output != entities.ErrDiffToken {
+			t.Errorf("expect[%+v], but got[%+v]", entities.ErrDiffToken, output)
+		}
+
+		_, output = AddLiquidityCallParameters(pair_0_1, amount(token0, 100), amount(token1, 100), &TradeOptions{
+			AllowedSlippage: entities.NewPercent(big.NewInt(101), big.NewInt(100)),
+			Deadline:        deadline,
+		})
+		if output != entities.ErrInvalidSlippageTolerance {
+			t.Errorf("expect[%+v], but got[%+v]", entities.ErrInvalidSlippageTolerance, output)
+		}
+	}
+}
+
+// nolint funlen
+func TestRemoveLiquidityCallParameters(t *testing.T) {
+	token0, _ := entities.NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000001"), 18, "t0", "")
+	token1, _ := entities.NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000002"), 18, "t1", "")
+	weth := entities.WETH[constants.Mainnet]
+	ether := entities.NewETHRToken(constants.Mainnet, weth.Address)
+
+	tokenAmount_0_1000, _ := entities.NewTokenAmount(token0, big.NewInt(1000))
+	tokenAmount_1_1000, _ := entities.NewTokenAmount(token1, big.NewInt(1000))
+	tokenAmount_1_2000, _ := entities.NewTokenAmount(token1, big.NewInt(2000))
+	tokenAmount_weth_1000, _ := entities.NewTokenAmount(weth, big.NewInt(1000))
+	pair_0_1, _ := entities.NewPair(tokenAmount_0_1000, tokenAmount_1_2000)
+	pair_0_1_even, _ := entities.NewPair(tokenAmount_0_1000, tokenAmount_1_1000)
+	pair_weth_0, _ := entities.NewPair(tokenAmount_weth_1000, tokenAmount_0_1000)
+
+	liquidity := func(pair *entities.Pair, raw int64) *entities.TokenAmount {
+		tokenAmount, err := entities.NewTokenAmount(pair.LiquidityToken, big.NewInt(raw))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tokenAmount
+	}
+
+	recipient := common.HexToAddress("0x0000000000000000000000000000000000000004")
+	deadline := int64(1700000000)
+	tradeOptions := &TradeOptions{
+		AllowedSlippage: entities.NewPercent(big.NewInt(1), big.NewInt(100)),
+		Deadline:        deadline,
+		Recipient:       recipient,
+	}
+	permit := &Permit{ApproveMax: true, V: 27, R: [32]byte{1}, S: [32]byte{2}}
+
+	// removes liquidity of tokens
+	{
+		result, err := RemoveLiquidityCallParameters(pair_0_1, token1, token0, liquidity(pair_0_1, 100), liquidity(pair_0_1, 1000),
+			&RemoveLiquidityOptions{TradeOptions: tradeOptions})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCall(t, result.SwapParameters, "removeLiquidity", big.NewInt(0),
+			token1.Address, token0.Address, big.NewInt(100), big.NewInt(198), big.NewInt(99), recipient, big.NewInt(deadline))
+		if result.AmountA.Raw().Int64() != 200 || result.AmountB.Raw().Int64() != 100 {
+			t.Errorf("expect[200 100], but got[%+v %+v]", result.AmountA.Raw(), result.AmountB.Raw())
+		}
+	}
+
+	// removes liquidity of tokens with permit
+	{
+		result, err := RemoveLiquidityCallParameters(pair_0_1, token0, token1, liquidity(pair_0_1, 100), liquidity(pair_0_1, 1000),
+			&RemoveLiquidityOptions{TradeOptions: tradeOptions, Permit: permit})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCall(t, result.SwapParameters, "removeLiquidityWithPermit", big.NewInt(0),
+			token0.Address, token1.Address, big.NewInt(100), big.NewInt(99), big.NewInt(198), recipient, big.NewInt(deadline),
+			true, uint8(27), [32]byte{1}, [32]byte{2})
+	}
+
+	// removes liquidity with the protocol fee on
+	{
+		result, err := RemoveLiquidityCallParameters(pair_0_1_even, token0, token1, liquidity(pair_0_1_even, 500),
+			liquidity(pair_0_1_even, 500), &RemoveLiquidityOptions{TradeOptions: tradeOptions, FeeOn: true, KLast: big.NewInt(250000)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.AmountA.Raw().Int64() != 917 || result.AmountBMin.Raw().Int64() != 907 {
+			t.Errorf("expect[917 907], but got[%+v %+v]", result.AmountA.Raw(), result.AmountBMin.Raw())
+		}
+	}
+
+	// removes liquidity with ether
+	{
+		tests := []struct {
+			feeOnTransfer bool
+			permit        *Permit
+			methodName    string
+		}{
+			{false, nil, "removeLiquidityETH"},
+			{false, permit, "removeLiquidityETHWithPermit"},
+			{true, nil, "removeLiquidityETHSupportingFeeOnTransferTokens"},
+			{true, permit, "removeLiquidityETHWithPermitSupportingFeeOnTransferTokens"},
+		}
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.methodName, func(t *testing.T) {
+				options := *tradeOptions
+				options.FeeOnTransfer = tt.feeOnTransfer
+				result, err := RemoveLiquidityCallParameters(pair_weth_0, ether, token0, liquidity(pair_weth_0, 100),
+					liquidity(pair_weth_0, 1000), &RemoveLiquidityOptions{TradeOptions: &options, Permit: tt.permit})
+				if err != nil {
+					t.Fatal(err)
+				}
+				args := []interface{}{token0.Address, big.NewInt(100), big.NewInt(99), big.NewInt(99), recipient, big.NewInt(deadline)}
+				if tt.permit != nil {
+					args = append(args, true, uint8(27), [32]byte{1}, [32]byte{2})
+				}
+				assertCall(t, result.SwapParameters, tt.methodName, big.NewInt(0), args...)
+			})
+		}
+	}
+
+	// errors
+	{
+		options := *tradeOptions
+		options.FeeOnTransfer = true
+		_, output := RemoveLiquidityCallParameters(pair_0_1, token0, token1, liquidity(pair_0_1, 100), liquidity(pair_0_1, 1000),
+			&RemoveLiquidityOptions{TradeOptions: &options})
+		if output != ErrRemoveFeeOnTransfer {
+			t.Errorf("expect[%+v], but got[%+v]", ErrRemoveFeeOnTransfer, output)
+		}
+
+		_, output = RemoveLiquidityCallParameters(pair_0_1, token0, token1, liquidity(pair_0_1, 100), liquidity(pair_0_1, 1000),
+			&RemoveLiquidityOptions{TradeOptions: tradeOptions, FeeOn: true})
+		if output != entities.ErrInvalidKLast {
+			t.Errorf("expect[%+v], but got[%+v]", entities.ErrInvalidKLast, output)
+		}
+	}
+}
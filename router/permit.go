@@ -0,0 +1,116 @@
+package router
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/miraclesu/uniswap-sdk-go/entities"
+)
+
+var (
+	// ErrInvalidSignature the signer returned a malformed signature
+	ErrInvalidSignature = errors.New("invalid signature")
+
+	// ref: https://github.com/Uniswap/uniswap-v2-core/blob/master/contracts/UniswapV2ERC20.sol
+	domainTypeHash = crypto.Keccak256Hash([]byte(
+		"EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"))
+	permitTypeHash = crypto.Keccak256Hash([]byte(
+		"Permit(address owner,address spender,uint256 value,uint256 nonce,uint256 deadline)"))
+	permitVersion = "1"
+)
+
+// PermitMessage the EIP-2612 permit of the liquidity token of a pair.
+type PermitMessage struct {
+	// the liquidity provider.
+	Owner common.Address
+	// the account allowed to spend the liquidity, usually the router.
+	Spender common.Address
+	// the amount of liquidity allowed to spend, the max uint256 approves the max.
+	Value *big.Int
+	// the nonces(owner) of the pair.
+	Nonce *big.Int
+	// unix timestamp in seconds, the same deadline must be used by the remove liquidity call.
+	Deadline *big.Int
+}
+
+// Signer signs the digest of a permit, such as a hardware wallet or a remote signer.
+type Signer interface {
+	// SignHash returns the 65 bytes [R || S || V] signature of the hash, V is 0/1 or 27/28.
+	SignHash(hash []byte) ([]byte, error)
+}
+
+// KeySigner signs with a private key.
+type KeySigner struct {
+	key *ecdsa.PrivateKey
+}
+
+// NewKeySigner creates a KeySigner
+func NewKeySigner(key *ecdsa.PrivateKey) *KeySigner {
+	return &KeySigner{key: key}
+}
+
+// SignHash signs the hash with the private key
+func (s *KeySigner) SignHash(hash []byte) ([]byte, error) {
+	return crypto.Sign(hash, s.key)
+}
+
+// DomainSeparator returns the EIP-712 domain separator of the liquidity token of the pair,
+// same as the DOMAIN_SEPARATOR of the pair.
+func DomainSeparator(pair *entities.Pair) common.Hash {
+	address := pair.GetAddress()
+	return crypto.Keccak256Hash(
+		domainTypeHash.Bytes(),
+		crypto.Keccak256([]byte(pair.LiquidityToken.Name)),
+		crypto.Keccak256([]byte(permitVersion)),
+		math.U256Bytes(big.NewInt(int64(pair.ChainID()))),
+		common.LeftPadBytes(address.Bytes(), 32),
+	)
+}
+
+// PermitDigest returns the EIP-712 digest of the permit of the liquidity token of the pair.
+func PermitDigest(pair *entities.Pair, message *PermitMessage) common.Hash {
+	structHash := crypto.Keccak256(
+		permitTypeHash.Bytes(),
+		common.LeftPadBytes(message.Owner.Bytes(), 32),
+		common.LeftPadBytes(message.Spender.Bytes(), 32),
+		math.U256Bytes(big.NewInt(0).Set(message.Value)),
+		math.U256Bytes(big.NewInt(0).Set(message.Nonce)),
+		math.U256Bytes(big.NewInt(0).Set(message.Deadline)),
+	)
+	return crypto.Keccak256Hash([]byte{0x19, 0x01}, DomainSeparator(pair).Bytes(), structHash)
+}
+
+// SignPermit signs the permit of the liquidity token of the pair, the returned Permit can be used by
+// RemoveLiquidityCallParameters.
+// @param pair the pair of the liquidity token
+// @param message the permit, ApproveMax of the returned Permit is set if its value is the max uint256
+// @param signer the signer of the owner
+func SignPermit(pair *entities.Pair, message *PermitMessage, signer Signer) (*Permit, error) {
+	digest := PermitDigest(pair, message)
+	signature, err := signer.SignHash(digest.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	if len(signature) != crypto.SignatureLength {
+		return nil, ErrInvalidSignature
+	}
+
+	permit := &Permit{
+		ApproveMax: message.Value.Cmp(math.MaxBig256) == 0,
+		V:          signature[crypto.RecoveryIDOffset],
+	}
+	if permit.V < 27 {
+		permit.V += 27
+	}
+	if permit.V != 27 && permit.V != 28 {
+		return nil, ErrInvalidSignature
+	}
+	copy(permit.R[:], signature[:32])
+	copy(permit.S[:], signature[32:64])
+	return permit, nil
+}
@@ -0,0 +1,108 @@
+package router
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+	"github.com/miraclesu/uniswap-sdk-go/entities"
+)
+
+type badSigner struct{}
+
+func (badSigner) SignHash(hash []byte) ([]byte, error) {
+	return hash, nil
+}
+
+// nolint funlen
+func TestSignPermit(t *testing.T) {
+	token0, _ := entities.NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000001"), 18, "t0", "")
+	token1, _ := entities.NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000002"), 18, "t1", "")
+	tokenAmount_0_100, _ := entities.NewTokenAmount(token0, big.NewInt(100))
+	tokenAmount_1_100, _ := entities.NewTokenAmount(token1, big.NewInt(100))
+	pair_0_1, _ := entities.NewPair(tokenAmount_0_100, tokenAmount_1_100)
+	pancake_0_1, _ := entities.NewPairWithProtocol(tokenAmount_0_100, tokenAmount_1_100, entities.PancakeSwapV2)
+
+	key, err := crypto.HexToECDSA("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner := crypto.PubkeyToAddress(key.PublicKey)
+	message := &PermitMessage{
+		Owner:    owner,
+		Spender:  common.HexToAddress("0x7a250d5630B4cF539739dF2C5dAcb4c659F2488D"),
+		Value:    big.NewInt(100),
+		Nonce:    big.NewInt(0),
+		Deadline: big.NewInt(1700000000),
+	}
+
+	// the vectors are produced by the EIP-712 typed data hashing of go-ethereum's signer/core, as eth_signTypedData
+	// does, for the pair 0xF60dDd2C94A754f0C2bC4770fE0fFF6e526fDeF8 of token0 and token1
+	{
+		// PERMIT_TYPEHASH of UniswapV2ERC20.sol
+		expect := "0x6e71edae12b1b97f4d1f60370fef10105fa2faae0126114a169c64845d6126c9"
+		if output := permitTypeHash.Hex(); output != expect {
+			t.Errorf("expect[%+v], but got[%+v]", expect, output)
+		}
+	}
+	{
+		expect := "0x4a8efc63e05ae47a99d6200b4e653bcdbbf1b1c7d5b487fbafac106e5261b4d6"
+		if output := DomainSeparator(pair_0_1).Hex(); output != expect {
+			t.Errorf("expect[%+v], but got[%+v]", expect, output)
+		}
+		// the domain separator depends on the liquidity token name and the pair address
+		if output := DomainSeparator(pancake_0_1).Hex(); output == expect {
+			t.Error("should differ for another protocol")
+		}
+	}
+	{
+		expect := "0x4f6e2bc117081368bc9d1b8ce3bd0dd17a0c05229acd5b59fc0c21ed72410f3e"
+		if output := PermitDigest(pair_0_1, message).Hex(); output != expect {
+			t.Errorf("expect[%+v], but got[%+v]", expect, output)
+		}
+	}
+
+	// signs the permit digest
+	{
+		permit, err := SignPermit(pair_0_1, message, NewKeySigner(key))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if permit.ApproveMax {
+			t.Error("should not approve max")
+		}
+		expect := &Permit{
+			V: 28,
+			R: common.HexToHash("0x41e6093a3ec1013551d92efac6e8dd7c737008116d7a00c6e8a5a70d541047d1"),
+			S: common.HexToHash("0x08b76d71b51a6da0a432aa1e989f6bbe0d115d3121289f73df759a135f074f40"),
+		}
+		if permit.V != expect.V || permit.R != expect.R || permit.S != expect.S {
+			t.Errorf("expect[%+v], but got[%+v]", expect, permit)
+		}
+	}
+
+	// approves max
+	{
+		max := *message
+		max.Value = math.MaxBig256
+		permit, err := SignPermit(pair_0_1, &max, NewKeySigner(key))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !permit.ApproveMax {
+			t.Error("should approve max")
+		}
+	}
+
+	// throws for a malformed signature
+	{
+		_, output := SignPermit(pair_0_1, message, badSigner{})
+		if output != ErrInvalidSignature {
+			t.Errorf("expect[%+v], but got[%+v]", ErrInvalidSignature, output)
+		}
+	}
+}
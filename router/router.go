@@ -0,0 +1,153 @@
+package router
+
+import (
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+	"github.com/miraclesu/uniswap-sdk-go/entities"
+)
+
+var (
+	// ErrEtherInOut the router does not support both ether in and out
+	ErrEtherInOut = errors.New("ether in and out")
+	// ErrInvalidTTL ttl must be greater than zero if deadline is not set
+	ErrInvalidTTL = errors.New("invalid ttl")
+	// ErrExactOutFeeOnTransfer fee on transfer tokens are only supported for exact input trades
+	ErrExactOutFeeOnTransfer = errors.New("exact out fee on transfer")
+	// ErrRemoveFeeOnTransfer fee on transfer tokens are only supported for removing liquidity with ETH
+	ErrRemoveFeeOnTransfer = errors.New("remove liquidity fee on transfer")
+)
+
+// TradeOptions options for producing the arguments to send call to the router.
+type TradeOptions struct {
+	// how much the execution price is allowed to move unfavorably from the trade execution price.
+	AllowedSlippage *entities.Percent
+	// how long the swap is valid until it expires, in seconds.
+	// this will be used to produce a `deadline` parameter which is computed from when the swap call parameters
+	// are generated. it is ignored when Deadline is set.
+	TTL int64
+	// when the transaction expires, unix timestamp in seconds. takes precedence over TTL.
+	Deadline int64
+	// the account that should receive the output of the swap.
+	Recipient common.Address
+	// whether any of the tokens in the path are fee on transfer tokens, which should be handled with special methods.
+	// it is implied if any token declares transfer fees.
+	FeeOnTransfer bool
+}
+
+func (o *TradeOptions) deadline() (*big.Int, error) {
+	if o.Deadline > 0 {
+		return big.NewInt(o.Deadline), nil
+	}
+	if o.TTL <= 0 {
+		return nil, ErrInvalidTTL
+	}
+	return big.NewInt(time.Now().Unix() + o.TTL), nil
+}
+
+// SwapParameters the parameters to use in the call to the Uniswap V2 Router to execute a trade.
+type SwapParameters struct {
+	// the method to call on the Uniswap V2 Router.
+	MethodName string
+	// the arguments to pass to the method, in the go-ethereum abi types.
+	Args []interface{}
+	// the ABI encoded method call, i.e. the 4 bytes selector followed by the packed Args.
+	Calldata hexutil.Bytes
+	// the amount of wei to send.
+	Value *big.Int
+}
+
+func newSwapParameters(methodName string, value *big.Int, args ...interface{}) (*SwapParameters, error) {
+	calldata, err := ABI.Pack(methodName, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SwapParameters{
+		MethodName: methodName,
+		Args:       args,
+		Calldata:   calldata,
+		Value:      value,
+	}, nil
+}
+
+// SwapCallParameters produces the on-chain method name to call and the hex encoded parameters to pass as arguments
+// for a given trade.
+// @param trade to produce call parameters for
+// @param options options for the call parameters
+// nolint gocyclo
+func SwapCallParameters(trade *entities.Trade, options *TradeOptions) (*SwapParameters, error) {
+	etherIn := trade.Route.Input.Currency.IsNative()
+	etherOut := trade.Route.Output.Currency.IsNative()
+	// the router does not support both ether in and out
+	if etherIn && etherOut {
+		return nil, ErrEtherInOut
+	}
+
+	deadline, err := options.deadline()
+	if err != nil {
+		return nil, err
+	}
+	amountIn, err := trade.MaximumAmountIn(options.AllowedSlippage)
+	if err != nil {
+		return nil, err
+	}
+	amountOut, err := trade.MinimumAmountOut(options.AllowedSlippage)
+	if err != nil {
+		return nil, err
+	}
+
+	feeOnTransfer := options.FeeOnTransfer || trade.FeeOnTransfer()
+	to := options.Recipient
+	path := make([]common.Address, len(trade.Route.Path))
+	for i := range trade.Route.Path {
+		path[i] = trade.Route.Path[i].Address
+	}
+	zero := big.NewInt(0)
+
+	if trade.TradeType == constants.ExactInput {
+		switch {
+		case etherIn:
+			methodName := "swapExactETHForTokens"
+			if feeOnTransfer {
+				methodName = "swapExactETHForTokensSupportingFeeOnTransferTokens"
+			}
+			// (uint amountOutMin, address[] calldata path, address to, uint deadline)
+			return newSwapParameters(methodName, amountIn.Raw(), amountOut.Raw(), path, to, deadline)
+		case etherOut:
+			methodName := "swapExactTokensForETH"
+			if feeOnTransfer {
+				methodName = "swapExactTokensForETHSupportingFeeOnTransferTokens"
+			}
+			// (uint amountIn, uint amountOutMin, address[] calldata path, address to, uint deadline)
+			return newSwapParameters(methodName, zero, amountIn.Raw(), amountOut.Raw(), path, to, deadline)
+		default:
+			methodName := "swapExactTokensForTokens"
+			if feeOnTransfer {
+				methodName = "swapExactTokensForTokensSupportingFeeOnTransferTokens"
+			}
+			// (uint amountIn, uint amountOutMin, address[] calldata path, address to, uint deadline)
+			return newSwapParameters(methodName, zero, amountIn.Raw(), amountOut.Raw(), path, to, deadline)
+		}
+	}
+
+	if feeOnTransfer {
+		return nil, ErrExactOutFeeOnTransfer
+	}
+	switch {
+	case etherIn:
+		// (uint amountOut, address[] calldata path, address to, uint deadline)
+		return newSwapParameters("swapETHForExactTokens", amountIn.Raw(), amountOut.Raw(), path, to, deadline)
+	case etherOut:
+		// (uint amountOut, uint amountInMax, address[] calldata path, address to, uint deadline)
+		return newSwapParameters("swapTokensForExactETH", zero, amountOut.Raw(), amountIn.Raw(), path, to, deadline)
+	default:
+		// (uint amountOut, uint amountInMax, address[] calldata path, address to, uint deadline)
+		return newSwapParameters("swapTokensForExactTokens", zero, amountOut.Raw(), amountIn.Raw(), path, to, deadline)
+	}
+}
@@ -0,0 +1,302 @@
+package router
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+	"github.com/miraclesu/uniswap-sdk-go/entities"
+)
+
+// nolint funlen
+func TestSwapCallParameters(t *testing.T) {
+	token0, _ := entities.NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000001"), 18, "t0", "")
+	token1, _ := entities.NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000002"), 18, "t1", "")
+	weth := entities.WETH[constants.Mainnet]
+	ether := entities.NewETHRToken(constants.Mainnet, weth.Address)
+
+	tokenAmount_0_1000, _ := entities.NewTokenAmount(token0, big.NewInt(1000))
+	tokenAmount_1_1000, _ := entities.NewTokenAmount(token1, big.NewInt(1000))
+	tokenAmount_weth_1000, _ := entities.NewTokenAmount(weth, big.NewInt(1000))
+	pair_0_1, _ := entities.NewPair(tokenAmount_0_1000, tokenAmount_1_1000)
+	pair_weth_0, _ := entities.NewPair(tokenAmount_weth_1000, tokenAmount_0_1000)
+
+	tokenAmount_0_100, _ := entities.NewTokenAmount(token0, big.NewInt(100))
+	tokenAmount_1_100, _ := entities.NewTokenAmount(token1, big.NewInt(100))
+	tokenAmount_ether_100, _ := entities.NewTokenAmount(ether, big.NewInt(100))
+
+	route_ether_1, _ := entities.NewRoute([]*entities.Pair{pair_weth_0, pair_0_1}, ether, token1)
+	route_1_ether, _ := entities.NewRoute([]*entities.Pair{pair_0_1, pair_weth_0}, token1, ether)
+	route_0_1, _ := entities.NewRoute([]*entities.Pair{pair_0_1}, token0, token1)
+
+	mustTrade := func(trade *entities.Trade, err error) *entities.Trade {
+		if err != nil {
+			t.Fatal(err)
+		}
+		return trade
+	}
+
+	recipient := common.HexToAddress("0x0000000000000000000000000000000000000004")
+	slippage := entities.NewPercent(big.NewInt(1), big.NewInt(100))
+	deadline := int64(1700000000)
+
+	tests := []struct {
+		name          string
+		trade         *entities.Trade
+		feeOnTransfer bool
+		methodName    string
+		args          []interface{}
+		value         *big.Int
+	}{
+		{
+			"exact in ether to token1",
+			mustTrade(entities.ExactIn(route_ether_1, tokenAmount_ether_100)),
+			false,
+			"swapExactETHForTokens",
+			[]interface{}{big.NewInt(0x51), []common.Address{weth.Address, token0.Address, token1.Address}, recipient},
+			big.NewInt(0x64),
+		},
+		{
+			"exact in token1 to ether",
+			mustTrade(entities.ExactIn(route_1_ether, tokenAmount_1_100)),
+			false,
+			"swapExactTokensForETH",
+			[]interface{}{big.NewInt(0x64), big.NewInt(0x51), []common.Address{token1.Address, token0.Address, weth.Address}, recipient},
+			big.NewInt(0),
+		},
+		{
+			"exact in token0 to token1",
+			mustTrade(entities.ExactIn(route_0_1, tokenAmount_0_100)),
+			false,
+			"swapExactTokensForTokens",
+			[]interface{}{big.NewInt(0x64), big.NewInt(0x59), []common.Address{token0.Address, token1.Address}, recipient},
+			big.NewInt(0),
+		},
+		{
+			"exact out ether to token1",
+			mustTrade(entities.ExactOut(route_ether_1, tokenAmount_1_100)),
+			false,
+			"swapETHForExactTokens",
+			[]interface{}{big.NewInt(0x64), []common.Address{weth.Address, token0.Address, token1.Address}, recipient},
+			big.NewInt(0x80),
+		},
+		{
+			"exact out token1 to ether",
+			mustTrade(entities.ExactOut(route_1_ether, tokenAmount_ether_100)),
+			false,
+			"swapTokensForExactETH",
+			[]interface{}{big.NewInt(0x64), big.NewInt(0x80), []common.Address{token1.Address, token0.Address, weth.Address}, recipient},
+			big.NewInt(0),
+		},
+		{
+			"exact out token0 to token1",
+			mustTrade(entities.ExactOut(route_0_1, tokenAmount_1_100)),
+			false,
+			"swapTokensForExactTokens",
+			[]interface{}{big.NewInt(0x64), big.NewInt(0x71), []common.Address{token0.Address, token1.Address}, recipient},
+			big.NewInt(0),
+		},
+		{
+			"fee on transfer exact in ether to token1",
+			mustTrade(entities.ExactIn(route_ether_1, tokenAmount_ether_100)),
+			true,
+			"swapExactETHForTokensSupportingFeeOnTransferTokens",
+			[]interface{}{big.NewInt(0x51), []common.Address{weth.Address, token0.Address, token1.Address}, recipient},
+			big.NewInt(0x64),
+		},
+		{
+			"fee on transfer exact in token1 to ether",
+			mustTrade(entities.ExactIn(route_1_ether, tokenAmount_1_100)),
+			true,
+			"swapExactTokensForETHSupportingFeeOnTransferTokens",
+			[]interface{}{big.NewInt(0x64), big.NewInt(0x51), []common.Address{token1.Address, token0.Address, weth.Address}, recipient},
+			big.NewInt(0),
+		},
+		{
+			"fee on transfer exact in token0 to token1",
+			mustTrade(entities.ExactIn(route_0_1, tokenAmount_0_100)),
+			true,
+			"swapExactTokensForTokensSupportingFeeOnTransferTokens",
+			[]interface{}{big.NewInt(0x64), big.NewInt(0x59), []common.Address{token0.Address, token1.Address}, recipient},
+			big.NewInt(0),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := SwapCallParameters(tt.trade, &TradeOptions{
+				AllowedSlippage: slippage,
+				Deadline:        deadline,
+				Recipient:       recipient,
+				FeeOnTransfer:   tt.feeOnTransfer,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.MethodName != tt.methodName {
+				t.Errorf("MethodName = %v, want %v", result.MethodName, tt.methodName)
+			}
+			if result.Value.Cmp(tt.value) != 0 {
+				t.Errorf("Value = %v, want %v", result.Value, tt.value)
+			}
+			args := append(tt.args, big.NewInt(deadline))
+			if len(result.Args) != len(args) {
+				t.Fatalf("Args = %v, want %v", result.Args, args)
+			}
+			for i := range args {
+				if !argEqual(result.Args[i], args[i]) {
+					t.Errorf("Args[%d] = %v, want %v", i, result.Args[i], args[i])
+				}
+			}
+			method := ABI.Methods[tt.methodName]
+			if !bytes.Equal(result.Calldata[:4], method.ID) {
+				t.Errorf("Calldata selector = %x, want %x", result.Calldata[:4], method.ID)
+			}
+			unpacked, err := method.Inputs.Unpack(result.Calldata[4:])
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := range args {
+				if !argEqual(unpacked[i], args[i]) {
+					t.Errorf("Calldata arg %d = %v, want %v", i, unpacked[i], args[i])
+				}
+			}
+		})
+	}
+
+	// uses ttl to compute the deadline
+	{
+		now := time.Now().Unix()
+		result, err := SwapCallParameters(mustTrade(entities.ExactIn(route_0_1, tokenAmount_0_100)), &TradeOptions{
+			AllowedSlippage: slippage,
+			TTL:             50,
+			Recipient:       recipient,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		output := result.Args[len(result.Args)-1].(*big.Int).Int64()
+		if output < now+50 || output > time.Now().Unix()+50 {
+			t.Errorf("deadline[%d] is not in [%d, %d]", output, now+50, time.Now().Unix()+50)
+		}
+	}
+
+	// errors
+	{
+		var tests = []struct {
+			trade   *entities.Trade
+			options *TradeOptions
+			expect  error
+		}{
+			{
+				mustTrade(entities.ExactIn(route_0_1, tokenAmount_0_100)),
+				&TradeOptions{AllowedSlippage: slippage, Recipient: recipient},
+				ErrInvalidTTL,
+			},
+			{
+				mustTrade(entities.ExactOut(route_0_1, tokenAmount_1_100)),
+				&TradeOptions{AllowedSlippage: slippage, TTL: 50, Recipient: recipient, FeeOnTransfer: true},
+				ErrExactOutFeeOnTransfer,
+			},
+			{
+				mustTrade(entities.ExactIn(route_0_1, tokenAmount_0_100)),
+				&TradeOptions{AllowedSlippage: entities.NewPercent(big.NewInt(-1), big.NewInt(100)), TTL: 50},
+				entities.ErrInvalidSlippageTolerance,
+			},
+		}
+		for i, test := range tests {
+			_, output := SwapCallParameters(test.trade, test.options)
+			if test.expect != output {
+				t.Errorf("test #%d: expect[%+v], but got[%+v]", i, test.expect, output)
+			}
+		}
+	}
+}
+
+// nolint funlen
+func TestSwapCallParametersTransferFees(t *testing.T) {
+	token0, _ := entities.NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000001"), 18, "t0", "")
+	token1, _ := entities.NewToken(constants.Mainnet, common.HexToAddress("0x0000000000000000000000000000000000000002"), 18, "t1", "")
+	token1, err := token1.WithTransferFees(entities.NewPercent(big.NewInt(1), big.NewInt(100)),
+		entities.NewPercent(big.NewInt(1), big.NewInt(100)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tokenAmount_0_1000, _ := entities.NewTokenAmount(token0, big.NewInt(1000))
+	tokenAmount_1_1000, _ := entities.NewTokenAmount(token1, big.NewInt(1000))
+	tokenAmount_0_100, _ := entities.NewTokenAmount(token0, big.NewInt(100))
+	tokenAmount_1_100, _ := entities.NewTokenAmount(token1, big.NewInt(100))
+	pair_0_1, _ := entities.NewPair(tokenAmount_0_1000, tokenAmount_1_1000)
+	route_0_1, _ := entities.NewRoute([]*entities.Pair{pair_0_1}, token0, token1)
+
+	options := &TradeOptions{
+		AllowedSlippage: entities.NewPercent(big.NewInt(1), big.NewInt(100)),
+		Deadline:        1700000000,
+		Recipient:       common.HexToAddress("0x0000000000000000000000000000000000000004"),
+	}
+
+	// implies the supporting fee on transfer methods
+	{
+		trade, err := entities.ExactIn(route_0_1, tokenAmount_0_100)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := SwapCallParameters(trade, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect := "swapExactTokensForTokensSupportingFeeOnTransferTokens"
+		if result.MethodName != expect {
+			t.Errorf("expect[%+v], but got[%+v]", expect, result.MethodName)
+		}
+	}
+
+	// throws for exact output
+	{
+		trade, err := entities.ExactOut(route_0_1, tokenAmount_1_100)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, output := SwapCallParameters(trade, options)
+		if output != ErrExactOutFeeOnTransfer {
+			t.Errorf("expect[%+v], but got[%+v]", ErrExactOutFeeOnTransfer, output)
+		}
+	}
+}
+
+func argEqual(a, b interface{}) bool {
+	switch v := b.(type) {
+	case *big.Int:
+		u, ok := a.(*big.Int)
+		return ok && u.Cmp(v) == 0
+	case common.Address:
+		u, ok := a.(common.Address)
+		return ok && u == v
+	case bool:
+		u, ok := a.(bool)
+		return ok && u == v
+	case uint8:
+		u, ok := a.(uint8)
+		return ok && u == v
+	case [32]byte:
+		u, ok := a.([32]byte)
+		return ok && u == v
+	case []common.Address:
+		u, ok := a.([]common.Address)
+		if !ok || len(u) != len(v) {
+			return false
+		}
+		for i := range v {
+			if u[i] != v[i] {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,169 @@
+package tokenlist
+
+import (
+	"reflect"
+	"sort"
+)
+
+// VersionUpgrade the kind of version bump of a list
+type VersionUpgrade int
+
+const (
+	// NoUpgrade the version is not bumped
+	NoUpgrade VersionUpgrade = iota
+	// PatchUpgrade the details of existing tokens changed
+	PatchUpgrade
+	// MinorUpgrade tokens were added
+	MinorUpgrade
+	// MajorUpgrade tokens were removed
+	MajorUpgrade
+)
+
+func (u VersionUpgrade) String() string {
+	switch u {
+	case NoUpgrade:
+		return "none"
+	case PatchUpgrade:
+		return "patch"
+	case MinorUpgrade:
+		return "minor"
+	case MajorUpgrade:
+		return "major"
+	}
+	return "unknown"
+}
+
+// Upgrade returns the kind of version bump from the version to the other version
+func (v Version) Upgrade(to Version) VersionUpgrade {
+	switch {
+	case to.Major > v.Major:
+		return MajorUpgrade
+	case to.Major < v.Major:
+		return NoUpgrade
+	case to.Minor > v.Minor:
+		return MinorUpgrade
+	case to.Minor < v.Minor:
+		return NoUpgrade
+	case to.Patch > v.Patch:
+		return PatchUpgrade
+	}
+	return NoUpgrade
+}
+
+// Bump returns the version bumped by the upgrade
+func (v Version) Bump(upgrade VersionUpgrade) Version {
+	switch upgrade {
+	case MajorUpgrade:
+		return Version{Major: v.Major + 1}
+	case MinorUpgrade:
+		return Version{Major: v.Major, Minor: v.Minor + 1}
+	case PatchUpgrade:
+		return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}
+	}
+	return v
+}
+
+// TokenChange a token in both lists whose details changed
+type TokenChange struct {
+	Base    *TokenInfo
+	Updated *TokenInfo
+	// the json names of the changed fields, e.g. "symbol"
+	Fields []string
+}
+
+// Diff the differences of the tokens between two lists, in the order of the tokens in the lists
+type Diff struct {
+	// the tokens only in the updated list
+	Added []*TokenInfo
+	// the tokens only in the base list
+	Removed []*TokenInfo
+	Changed []*TokenChange
+}
+
+// Upgrade returns the minimal version bump required by the differences: removing tokens is a major upgrade, adding
+// tokens is a minor upgrade, and changing the details of tokens is a patch upgrade
+func (d *Diff) Upgrade() VersionUpgrade {
+	switch {
+	case len(d.Removed) > 0:
+		return MajorUpgrade
+	case len(d.Added) > 0:
+		return MinorUpgrade
+	case len(d.Changed) > 0:
+		return PatchUpgrade
+	}
+	return NoUpgrade
+}
+
+// DiffTokenLists computes the differences of the tokens between the base list and the updated list, tokens are
+// identified by chain id and address
+func DiffTokenLists(base, updated *TokenList) *Diff {
+	baseTokens := make(map[tokenKey]*TokenInfo, len(base.Tokens))
+	for _, token := range base.Tokens {
+		baseTokens[token.key()] = token
+	}
+	updatedTokens := make(map[tokenKey]bool, len(updated.Tokens))
+
+	diff := new(Diff)
+	for _, token := range updated.Tokens {
+		key := token.key()
+		updatedTokens[key] = true
+		baseToken, ok := baseTokens[key]
+		if !ok {
+			diff.Added = append(diff.Added, token)
+			continue
+		}
+		if fields := changedFields(baseToken, token); len(fields) > 0 {
+			diff.Changed = append(diff.Changed, &TokenChange{Base: baseToken, Updated: token, Fields: fields})
+		}
+	}
+	for _, token := range base.Tokens {
+		if !updatedTokens[token.key()] {
+			diff.Removed = append(diff.Removed, token)
+		}
+	}
+	return diff
+}
+
+// MinimumVersionBump returns the minimal version bump from the base list to the updated list
+func MinimumVersionBump(base, updated *TokenList) VersionUpgrade {
+	return DiffTokenLists(base, updated).Upgrade()
+}
+
+func changedFields(a, b *TokenInfo) []string {
+	var fields []string
+	if a.Name != b.Name {
+		fields = append(fields, "name")
+	}
+	if a.Decimals != b.Decimals {
+		fields = append(fields, "decimals")
+	}
+	if a.Symbol != b.Symbol {
+		fields = append(fields, "symbol")
+	}
+	if a.LogoURI != b.LogoURI {
+		fields = append(fields, "logoURI")
+	}
+	if !sameTags(a.Tags, b.Tags) {
+		fields = append(fields, "tags")
+	}
+	if (len(a.Extensions) > 0 || len(b.Extensions) > 0) && !reflect.DeepEqual(a.Extensions, b.Extensions) {
+		fields = append(fields, "extensions")
+	}
+	return fields
+}
+
+// sameTags checks the tags are the same regardless of their order
+func sameTags(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = append([]string(nil), a...), append([]string(nil), b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
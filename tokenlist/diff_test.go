@@ -0,0 +1,92 @@
+package tokenlist
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestVersion(t *testing.T) {
+	v := Version{Major: 1, Minor: 2, Patch: 3}
+	tests := []struct {
+		To      Version
+		Upgrade VersionUpgrade
+		Compare int
+	}{
+		{To: Version{Major: 1, Minor: 2, Patch: 3}, Upgrade: NoUpgrade, Compare: 0},
+		{To: Version{Major: 1, Minor: 2, Patch: 4}, Upgrade: PatchUpgrade, Compare: -1},
+		{To: Version{Major: 1, Minor: 3, Patch: 0}, Upgrade: MinorUpgrade, Compare: -1},
+		{To: Version{Major: 2, Minor: 0, Patch: 0}, Upgrade: MajorUpgrade, Compare: -1},
+		{To: Version{Major: 1, Minor: 1, Patch: 9}, Upgrade: NoUpgrade, Compare: 1},
+	}
+	for _, test := range tests {
+		if output := v.Upgrade(test.To); output != test.Upgrade {
+			t.Errorf("%v: expect[%+v], but got[%+v]", test.To, test.Upgrade, output)
+		}
+		if output := v.Compare(test.To); output != test.Compare {
+			t.Errorf("%v: expect[%+v], but got[%+v]", test.To, test.Compare, output)
+		}
+		if test.Upgrade != NoUpgrade {
+			if output := v.Bump(test.Upgrade); output != test.To {
+				t.Errorf("expect[%+v], but got[%+v]", test.To, output)
+			}
+		}
+	}
+}
+
+func TestDiffTokenLists(t *testing.T) {
+	base := mustParse(t)
+
+	// no changes
+	{
+		updated := mustParse(t)
+		updated.Tokens[1].Tags = []string{"stablecoin"}
+		if output := MinimumVersionBump(base, updated); output != NoUpgrade {
+			t.Errorf("expect[%+v], but got[%+v]", NoUpgrade, output)
+		}
+	}
+
+	// changed
+	updated := mustParse(t)
+	updated.Tokens[0].Symbol = "wETH"
+	updated.Tokens[0].LogoURI = "https://example.com/weth.png"
+	diff := DiffTokenLists(base, updated)
+	if len(diff.Changed) != 1 || diff.Changed[0].Base != base.Tokens[0] || diff.Changed[0].Updated != updated.Tokens[0] {
+		t.Fatalf("unexpected changes[%+v]", diff.Changed)
+	}
+	{
+		expect := []string{"symbol", "logoURI"}
+		if output := diff.Changed[0].Fields; !reflect.DeepEqual(output, expect) {
+			t.Errorf("expect[%+v], but got[%+v]", expect, output)
+		}
+	}
+	if output := diff.Upgrade(); output != PatchUpgrade {
+		t.Errorf("expect[%+v], but got[%+v]", PatchUpgrade, output)
+	}
+
+	// added
+	added := &TokenInfo{ChainID: 1, Address: "0x1111111111111111111111111111111111111111", Name: "One", Decimals: 6, Symbol: "ONE"}
+	updated.Tokens = append(updated.Tokens, added)
+	diff = DiffTokenLists(base, updated)
+	if len(diff.Added) != 1 || diff.Added[0] != added {
+		t.Errorf("unexpected added[%+v]", diff.Added)
+	}
+	if output := diff.Upgrade(); output != MinorUpgrade {
+		t.Errorf("expect[%+v], but got[%+v]", MinorUpgrade, output)
+	}
+
+	// removed
+	updated.Tokens = updated.Tokens[1:]
+	diff = DiffTokenLists(base, updated)
+	if len(diff.Removed) != 1 || diff.Removed[0] != base.Tokens[0] {
+		t.Errorf("unexpected removed[%+v]", diff.Removed)
+	}
+	if output := diff.Upgrade(); output != MajorUpgrade {
+		t.Errorf("expect[%+v], but got[%+v]", MajorUpgrade, output)
+	}
+	{
+		expect := "2.0.0"
+		if output := base.Version.Bump(diff.Upgrade()).String(); output != expect {
+			t.Errorf("expect[%+v], but got[%+v]", expect, output)
+		}
+	}
+}
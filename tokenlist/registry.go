@@ -0,0 +1,118 @@
+package tokenlist
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+	"github.com/miraclesu/uniswap-sdk-go/entities"
+)
+
+var (
+	// ErrUnknownToken the token is not in the registry
+	ErrUnknownToken = fmt.Errorf("unknown token")
+	// ErrAmbiguousSymbol several tokens of the chain have the symbol
+	ErrAmbiguousSymbol = fmt.Errorf("ambiguous token symbol")
+)
+
+// Registry indexes the tokens of token lists by chain id, and address or symbol
+type Registry struct {
+	lk *sync.RWMutex
+	// chain id : token address : token
+	tokens map[constants.ChainID]map[common.Address]*entities.Token
+	// chain id : token symbol : tokens, in the order they were added
+	symbols map[constants.ChainID]map[string][]*entities.Token
+}
+
+// NewRegistry creates a Registry holding the tokens of the lists
+func NewRegistry(lists ...*TokenList) (*Registry, error) {
+	r := &Registry{
+		lk:      new(sync.RWMutex),
+		tokens:  make(map[constants.ChainID]map[common.Address]*entities.Token),
+		symbols: make(map[constants.ChainID]map[string][]*entities.Token),
+	}
+	for _, list := range lists {
+		if err := r.Add(list); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Add validates the list and adds its tokens to the registry, a token already in the registry is kept as is
+func (r *Registry) Add(list *TokenList) error {
+	if err := list.Validate(); err != nil {
+		return err
+	}
+	tokens := make([]*entities.Token, len(list.Tokens))
+	for i, info := range list.Tokens {
+		token, err := info.Token()
+		if err != nil {
+			return fmt.Errorf("tokens[%d]: %w", i, err)
+		}
+		tokens[i] = token
+	}
+
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	for _, token := range tokens {
+		r.add(token)
+	}
+	return nil
+}
+
+func (r *Registry) add(token *entities.Token) {
+	tokens, ok := r.tokens[token.ChainID]
+	if !ok {
+		tokens = make(map[common.Address]*entities.Token, 1)
+		r.tokens[token.ChainID] = tokens
+		r.symbols[token.ChainID] = make(map[string][]*entities.Token, 1)
+	}
+	if _, ok := tokens[token.Address]; ok {
+		return
+	}
+	tokens[token.Address] = token
+	r.symbols[token.ChainID][token.Symbol] = append(r.symbols[token.ChainID][token.Symbol], token)
+}
+
+// Token returns the token of the chain at the address
+func (r *Registry) Token(chainID constants.ChainID, address common.Address) (*entities.Token, error) {
+	r.lk.RLock()
+	defer r.lk.RUnlock()
+
+	token, ok := r.tokens[chainID][address]
+	if !ok {
+		return nil, ErrUnknownToken
+	}
+	return token, nil
+}
+
+// TokenBySymbol returns the token of the chain with the symbol, the symbol is case sensitive
+func (r *Registry) TokenBySymbol(chainID constants.ChainID, symbol string) (*entities.Token, error) {
+	r.lk.RLock()
+	defer r.lk.RUnlock()
+
+	tokens := r.symbols[chainID][symbol]
+	switch len(tokens) {
+	case 0:
+		return nil, ErrUnknownToken
+	case 1:
+		return tokens[0], nil
+	default:
+		return nil, ErrAmbiguousSymbol
+	}
+}
+
+// Tokens returns the tokens of the chain, in no particular order
+func (r *Registry) Tokens(chainID constants.ChainID) []*entities.Token {
+	r.lk.RLock()
+	defer r.lk.RUnlock()
+
+	tokens := make([]*entities.Token, 0, len(r.tokens[chainID]))
+	for _, token := range r.tokens[chainID] {
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
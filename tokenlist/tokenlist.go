@@ -0,0 +1,258 @@
+// Package tokenlist loads and validates token lists in the Uniswap token list format, see https://tokenlists.org,
+// and converts their tokens to entities.Token.
+package tokenlist
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+	"github.com/miraclesu/uniswap-sdk-go/entities"
+	"github.com/miraclesu/uniswap-sdk-go/utils"
+)
+
+// the limits of the token list schema
+const (
+	maxNameLength           = 20
+	maxTokens               = 10000
+	maxKeywords             = 20
+	maxKeywordLength        = 20
+	maxTags                 = 20
+	maxTagIDLength          = 10
+	maxTagNameLength        = 20
+	maxTagDescriptionLength = 200
+	maxTokenNameLength      = 40
+	maxTokenSymbolLength    = 20
+	maxTokenTags            = 10
+)
+
+var (
+	// ErrInvalidName the list name is empty, too long or has invalid characters
+	ErrInvalidName = fmt.Errorf("invalid list name")
+	// ErrInvalidTimestamp the list has no timestamp
+	ErrInvalidTimestamp = fmt.Errorf("invalid list timestamp")
+	// ErrInvalidVersion the version has negative numbers
+	ErrInvalidVersion = fmt.Errorf("invalid list version")
+	// ErrInvalidKeyword the keyword is empty, too long, duplicated or has invalid characters
+	ErrInvalidKeyword = fmt.Errorf("invalid list keyword")
+	// ErrInvalidTag the tag is undefined, or its id, name or description is invalid
+	ErrInvalidTag = fmt.Errorf("invalid tag")
+	// ErrInvalidLogoURI the logo uri is not an absolute uri
+	ErrInvalidLogoURI = fmt.Errorf("invalid logo uri")
+	// ErrInvalidTokens the list has no tokens or too many tokens
+	ErrInvalidTokens = fmt.Errorf("invalid number of tokens")
+	// ErrInvalidChainID the token chain id is not positive
+	ErrInvalidChainID = fmt.Errorf("invalid token chain id")
+	// ErrInvalidDecimals the token decimals is not a uint8
+	ErrInvalidDecimals = fmt.Errorf("invalid token decimals")
+	// ErrInvalidTokenName the token name is empty or too long
+	ErrInvalidTokenName = fmt.Errorf("invalid token name")
+	// ErrInvalidSymbol the token symbol is empty, too long or has whitespaces
+	ErrInvalidSymbol = fmt.Errorf("invalid token symbol")
+	// ErrDuplicateToken the list has several tokens with the same chain id and address
+	ErrDuplicateToken = fmt.Errorf("duplicate token")
+
+	namePattern           = regexp.MustCompile(`^[\w ]+$`)
+	tagIDPattern          = regexp.MustCompile(`^\w+$`)
+	tagDescriptionPattern = regexp.MustCompile(`^[ \w.,:]+$`)
+	symbolPattern         = regexp.MustCompile(`^\S+$`)
+)
+
+// Version the semantic version of a list
+type Version struct {
+	Major int `json:"major"`
+	Minor int `json:"minor"`
+	Patch int `json:"patch"`
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Compare returns -1, 0 or 1 if the version is lower than, equal to or greater than the other version
+func (v Version) Compare(other Version) int {
+	for _, diff := range []int{v.Major - other.Major, v.Minor - other.Minor, v.Patch - other.Patch} {
+		if diff < 0 {
+			return -1
+		}
+		if diff > 0 {
+			return 1
+		}
+	}
+	return 0
+}
+
+// TagDefinition the definition of a tag that can be associated with the tokens of a list
+type TagDefinition struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// TokenInfo the metadata of a token in a list
+type TokenInfo struct {
+	ChainID    constants.ChainID      `json:"chainId"`
+	Address    string                 `json:"address"`
+	Name       string                 `json:"name"`
+	Decimals   int                    `json:"decimals"`
+	Symbol     string                 `json:"symbol"`
+	LogoURI    string                 `json:"logoURI,omitempty"`
+	Tags       []string               `json:"tags,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// Token converts the token info to a Token
+func (t *TokenInfo) Token() (*entities.Token, error) {
+	return entities.NewTokenFromHex(t.ChainID, t.Address, utils.Lenient, t.Decimals, t.Symbol, t.Name)
+}
+
+// key returns the key identifying the token in a list
+func (t *TokenInfo) key() tokenKey {
+	return tokenKey{chainID: t.ChainID, address: common.HexToAddress(t.Address)}
+}
+
+type tokenKey struct {
+	chainID constants.ChainID
+	address common.Address
+}
+
+// TokenList a list of tokens, see https://github.com/Uniswap/token-lists for the schema
+type TokenList struct {
+	Name      string                    `json:"name"`
+	Timestamp time.Time                 `json:"timestamp"`
+	Version   Version                   `json:"version"`
+	Tokens    []*TokenInfo              `json:"tokens"`
+	Keywords  []string                  `json:"keywords,omitempty"`
+	Tags      map[string]*TagDefinition `json:"tags,omitempty"`
+	LogoURI   string                    `json:"logoURI,omitempty"`
+}
+
+// Parse parses and validates the JSON token list
+func Parse(data []byte) (*TokenList, error) {
+	list := new(TokenList)
+	if err := json.Unmarshal(data, list); err != nil {
+		return nil, err
+	}
+	if err := list.Validate(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Validate validates the list against the token list schema, and checks that the tokens are unique, only use the tags
+// defined by the list and that their mixed case addresses match their checksum
+// nolint gocyclo
+func (l *TokenList) Validate() error {
+	if !validString(l.Name, maxNameLength, namePattern) {
+		return ErrInvalidName
+	}
+	if l.Timestamp.IsZero() {
+		return ErrInvalidTimestamp
+	}
+	if l.Version.Major < 0 || l.Version.Minor < 0 || l.Version.Patch < 0 {
+		return ErrInvalidVersion
+	}
+	if !validURI(l.LogoURI) {
+		return ErrInvalidLogoURI
+	}
+
+	if len(l.Keywords) > maxKeywords {
+		return ErrInvalidKeyword
+	}
+	keywords := make(map[string]bool, len(l.Keywords))
+	for i, keyword := range l.Keywords {
+		if keywords[keyword] || !validString(keyword, maxKeywordLength, namePattern) {
+			return fmt.Errorf("keywords[%d]: %w", i, ErrInvalidKeyword)
+		}
+		keywords[keyword] = true
+	}
+
+	if len(l.Tags) > maxTags {
+		return ErrInvalidTag
+	}
+	for id, tag := range l.Tags {
+		if tag == nil || !validString(id, maxTagIDLength, tagIDPattern) ||
+			!validString(tag.Name, maxTagNameLength, namePattern) ||
+			!validString(tag.Description, maxTagDescriptionLength, tagDescriptionPattern) {
+			return fmt.Errorf("tags[%s]: %w", id, ErrInvalidTag)
+		}
+	}
+
+	if len(l.Tokens) == 0 || len(l.Tokens) > maxTokens {
+		return ErrInvalidTokens
+	}
+	tokens := make(map[tokenKey]bool, len(l.Tokens))
+	for i, token := range l.Tokens {
+		if err := l.validateToken(token); err != nil {
+			return fmt.Errorf("tokens[%d]: %w", i, err)
+		}
+		key := token.key()
+		if tokens[key] {
+			return fmt.Errorf("tokens[%d]: %w", i, ErrDuplicateToken)
+		}
+		tokens[key] = true
+	}
+	return nil
+}
+
+func (l *TokenList) validateToken(token *TokenInfo) error {
+	if token == nil {
+		return ErrInvalidTokens
+	}
+	if token.ChainID < 1 {
+		return ErrInvalidChainID
+	}
+	// the schema requires the 0x prefix, and mixed case addresses must match their checksum
+	if !strings.HasPrefix(token.Address, "0x") {
+		return utils.ErrAddressPrefix
+	}
+	if _, err := utils.ParseChainAddress(token.Address, token.ChainID, utils.Lenient); err != nil {
+		return err
+	}
+	if utils.ValidateSolidityTypeInstance(big.NewInt(int64(token.Decimals)), constants.Uint8) != nil {
+		return ErrInvalidDecimals
+	}
+	if !validString(token.Name, maxTokenNameLength, nil) {
+		return ErrInvalidTokenName
+	}
+	if !validString(token.Symbol, maxTokenSymbolLength, symbolPattern) {
+		return ErrInvalidSymbol
+	}
+	if !validURI(token.LogoURI) {
+		return ErrInvalidLogoURI
+	}
+	if len(token.Tags) > maxTokenTags {
+		return ErrInvalidTag
+	}
+	for _, tag := range token.Tags {
+		if _, ok := l.Tags[tag]; !ok {
+			return ErrInvalidTag
+		}
+	}
+	return nil
+}
+
+// validString checks the string is not empty, at most max characters long and matches the pattern if any
+func validString(s string, max int, pattern *regexp.Regexp) bool {
+	n := utf8.RuneCountInString(s)
+	if n == 0 || n > max || strings.TrimSpace(s) == "" {
+		return false
+	}
+	return pattern == nil || pattern.MatchString(s)
+}
+
+// validURI checks the optional uri is absolute, e.g. https:// or ipfs://
+func validURI(uri string) bool {
+	if uri == "" {
+		return true
+	}
+	u, err := url.Parse(uri)
+	return err == nil && u.Scheme != ""
+}
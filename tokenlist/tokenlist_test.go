@@ -0,0 +1,180 @@
+package tokenlist
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+	"github.com/miraclesu/uniswap-sdk-go/utils"
+)
+
+const testList = `{
+	"name": "Test List",
+	"timestamp": "2021-01-01T00:00:00.000Z",
+	"version": {"major": 1, "minor": 2, "patch": 3},
+	"keywords": ["test"],
+	"tags": {"stablecoin": {"name": "Stablecoin", "description": "Tokens pegged to the dollar"}},
+	"logoURI": "ipfs://QmNa8mQkrNKp1WEEeGjFezDmDeodkWRevGFN8JCV7b4Xir",
+	"tokens": [
+		{
+			"chainId": 1,
+			"address": "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2",
+			"name": "Wrapped Ether",
+			"decimals": 18,
+			"symbol": "WETH"
+		},
+		{
+			"chainId": 1,
+			"address": "0x6B175474E89094C44Da98b954EedeAC495271d0F",
+			"name": "Dai Stablecoin",
+			"decimals": 18,
+			"symbol": "DAI",
+			"tags": ["stablecoin"],
+			"logoURI": "https://example.com/dai.png"
+		},
+		{
+			"chainId": 3,
+			"address": "0xc778417E063141139Fce010982780140Aa0cD5Ab",
+			"name": "Wrapped Ether",
+			"decimals": 18,
+			"symbol": "WETH"
+		}
+	]
+}`
+
+func mustParse(t *testing.T) *TokenList {
+	list, err := Parse([]byte(testList))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return list
+}
+
+func TestParse(t *testing.T) {
+	list := mustParse(t)
+	{
+		expect := "1.2.3"
+		if output := list.Version.String(); output != expect {
+			t.Errorf("expect[%+v], but got[%+v]", expect, output)
+		}
+	}
+	{
+		expect := 3
+		if output := len(list.Tokens); output != expect {
+			t.Errorf("expect[%+v], but got[%+v]", expect, output)
+		}
+	}
+
+	token, err := list.Tokens[1].Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.ChainID != constants.Mainnet || token.Decimals != 18 || token.Symbol != "DAI" || token.Name != "Dai Stablecoin" {
+		t.Errorf("unexpected token[%+v]", token)
+	}
+
+	if _, err := Parse([]byte(`{"name": 1}`)); err == nil {
+		t.Error("should fail on invalid json")
+	}
+}
+
+// nolint funlen
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Modify func(l *TokenList)
+		Expect error
+	}{
+		{Name: "valid", Modify: func(l *TokenList) {}},
+		{Name: "empty name", Modify: func(l *TokenList) { l.Name = "" }, Expect: ErrInvalidName},
+		{Name: "long name", Modify: func(l *TokenList) { l.Name = "A very long token list name" }, Expect: ErrInvalidName},
+		{Name: "invalid name", Modify: func(l *TokenList) { l.Name = "Test-List" }, Expect: ErrInvalidName},
+		{Name: "negative version", Modify: func(l *TokenList) { l.Version.Minor = -1 }, Expect: ErrInvalidVersion},
+		{Name: "relative logo", Modify: func(l *TokenList) { l.LogoURI = "logo.png" }, Expect: ErrInvalidLogoURI},
+		{Name: "duplicate keyword", Modify: func(l *TokenList) { l.Keywords = []string{"a", "a"} }, Expect: ErrInvalidKeyword},
+		{Name: "invalid tag id", Modify: func(l *TokenList) {
+			l.Tags["stable-coin"] = &TagDefinition{Name: "Stablecoin", Description: "Stablecoin"}
+		}, Expect: ErrInvalidTag},
+		{Name: "no tokens", Modify: func(l *TokenList) { l.Tokens = nil }, Expect: ErrInvalidTokens},
+		{Name: "chain id", Modify: func(l *TokenList) { l.Tokens[0].ChainID = 0 }, Expect: ErrInvalidChainID},
+		{Name: "short address", Modify: func(l *TokenList) { l.Tokens[0].Address = "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc" }, Expect: utils.ErrAddressLength},
+		{Name: "no prefix address", Modify: func(l *TokenList) { l.Tokens[0].Address = "C02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2" }, Expect: utils.ErrAddressPrefix},
+		{Name: "hex address", Modify: func(l *TokenList) { l.Tokens[0].Address = "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Ccg" }, Expect: utils.ErrAddressHex},
+		{Name: "checksum", Modify: func(l *TokenList) { l.Tokens[0].Address = "0xc02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2" }, Expect: utils.ErrAddressChecksum},
+		{Name: "lowercase address", Modify: func(l *TokenList) { l.Tokens[0].Address = "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2" }},
+		{Name: "negative decimals", Modify: func(l *TokenList) { l.Tokens[0].Decimals = -1 }, Expect: ErrInvalidDecimals},
+		{Name: "large decimals", Modify: func(l *TokenList) { l.Tokens[0].Decimals = 256 }, Expect: ErrInvalidDecimals},
+		{Name: "max decimals", Modify: func(l *TokenList) { l.Tokens[0].Decimals = 255 }},
+		{Name: "empty token name", Modify: func(l *TokenList) { l.Tokens[0].Name = "" }, Expect: ErrInvalidTokenName},
+		{Name: "symbol with space", Modify: func(l *TokenList) { l.Tokens[0].Symbol = "W ETH" }, Expect: ErrInvalidSymbol},
+		{Name: "undefined tag", Modify: func(l *TokenList) { l.Tokens[0].Tags = []string{"wrapped"} }, Expect: ErrInvalidTag},
+		{Name: "duplicate token", Modify: func(l *TokenList) {
+			token := *l.Tokens[0]
+			token.Address = "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2"
+			l.Tokens = append(l.Tokens, &token)
+		}, Expect: ErrDuplicateToken},
+	}
+	for i, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			list := mustParse(t)
+			tests[i].Modify(list)
+			if output := list.Validate(); !errors.Is(output, test.Expect) {
+				t.Errorf("expect[%+v], but got[%+v]", test.Expect, output)
+			}
+		})
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	list := mustParse(t)
+	other := mustParse(t)
+	other.Tokens = []*TokenInfo{{
+		ChainID:  constants.Mainnet,
+		Address:  "0x1111111111111111111111111111111111111111",
+		Name:     "Other Dai",
+		Decimals: 6,
+		Symbol:   "DAI",
+	}}
+	registry, err := NewRegistry(list, other)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := registry.Token(constants.Mainnet, common.HexToAddress("0x6B175474E89094C44Da98b954EedeAC495271d0F"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.Symbol != "DAI" {
+		t.Errorf("expect[%+v], but got[%+v]", "DAI", token.Symbol)
+	}
+	if _, err := registry.Token(constants.Kovan, token.Address); err != ErrUnknownToken {
+		t.Errorf("expect[%+v], but got[%+v]", ErrUnknownToken, err)
+	}
+
+	weth, err := registry.TokenBySymbol(constants.Ropsten, "WETH")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if weth.ChainID != constants.Ropsten {
+		t.Errorf("expect[%+v], but got[%+v]", constants.Ropsten, weth.ChainID)
+	}
+	if _, err := registry.TokenBySymbol(constants.Mainnet, "DAI"); err != ErrAmbiguousSymbol {
+		t.Errorf("expect[%+v], but got[%+v]", ErrAmbiguousSymbol, err)
+	}
+	if _, err := registry.TokenBySymbol(constants.Mainnet, "USDC"); err != ErrUnknownToken {
+		t.Errorf("expect[%+v], but got[%+v]", ErrUnknownToken, err)
+	}
+	{
+		expect := 3
+		if output := len(registry.Tokens(constants.Mainnet)); output != expect {
+			t.Errorf("expect[%+v], but got[%+v]", expect, output)
+		}
+	}
+
+	other.Name = ""
+	if err := registry.Add(other); err != ErrInvalidName {
+		t.Errorf("expect[%+v], but got[%+v]", ErrInvalidName, err)
+	}
+}
@@ -0,0 +1,125 @@
+package utils
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+)
+
+// AddressMode the address parsing mode
+type AddressMode int
+
+const (
+	// Lenient accepts all lowercase or all uppercase addresses without prefix, and verifies the checksum of mixed case
+	// addresses
+	Lenient AddressMode = iota
+	// Strict only accepts checksummed addresses with the 0x prefix
+	Strict
+)
+
+var (
+	// ErrAddressPrefix the address has no 0x prefix in strict mode
+	ErrAddressPrefix = fmt.Errorf("address has no 0x prefix")
+	// ErrAddressLength the address does not have 40 hex digits
+	ErrAddressLength = fmt.Errorf("invalid address length")
+	// ErrAddressHex the address has non hex digits
+	ErrAddressHex = fmt.Errorf("invalid address hex")
+	// ErrAddressChecksum the address does not match its checksum
+	ErrAddressChecksum = fmt.Errorf("invalid address checksum")
+
+	// EIP1191ChainIDs the chains whose addresses are checksummed with the chain id as specified by EIP-1191, i.e. RSK
+	// mainnet and testnet, the other chains use EIP-55 checksums. It must not be modified concurrently with parsing.
+	EIP1191ChainIDs = map[constants.ChainID]bool{
+		30: true,
+		31: true,
+	}
+)
+
+// ParseAddress parses the hex address, verifying its EIP-55 checksum
+// @param address the hex address
+// @param mode the parsing mode, Lenient or Strict
+func ParseAddress(address string, mode AddressMode) (common.Address, error) {
+	return parseAddress(address, "", mode)
+}
+
+// MustParseAddress parses the hex address in Lenient mode and panics if it is invalid, it is meant for the address
+// literals of package variables
+func MustParseAddress(address string) common.Address {
+	result, err := ParseAddress(address, Lenient)
+	if err != nil {
+		panic(fmt.Sprintf("%s: %v", address, err))
+	}
+	return result
+}
+
+// ParseChainAddress parses the hex address of the chain, verifying its EIP-1191 checksum if the chain is one of
+// EIP1191ChainIDs, and its EIP-55 checksum otherwise
+// @param address the hex address
+// @param chainID the chain of the address
+// @param mode the parsing mode, Lenient or Strict
+func ParseChainAddress(address string, chainID constants.ChainID, mode AddressMode) (common.Address, error) {
+	return parseAddress(address, checksumPrefix(chainID), mode)
+}
+
+// ChecksumAddress returns the checksummed hex address of the chain, see ParseChainAddress
+func ChecksumAddress(address common.Address, chainID constants.ChainID) string {
+	return checksum(hex.EncodeToString(address[:]), checksumPrefix(chainID))
+}
+
+func checksumPrefix(chainID constants.ChainID) string {
+	if EIP1191ChainIDs[chainID] {
+		return strconv.Itoa(int(chainID)) + "0x"
+	}
+	return ""
+}
+
+func parseAddress(address, prefix string, mode AddressMode) (common.Address, error) {
+	digits := address
+	if strings.HasPrefix(digits, "0x") || strings.HasPrefix(digits, "0X") {
+		digits = digits[2:]
+	} else if mode == Strict {
+		return common.Address{}, ErrAddressPrefix
+	}
+	if len(digits) != 2*common.AddressLength {
+		return common.Address{}, ErrAddressLength
+	}
+	var result common.Address
+	if _, err := hex.Decode(result[:], []byte(digits)); err != nil {
+		return common.Address{}, ErrAddressHex
+	}
+
+	lower := strings.ToLower(digits)
+	if mode == Lenient && (digits == lower || digits == strings.ToUpper(digits)) {
+		return result, nil
+	}
+	if "0x"+digits != checksum(lower, prefix) {
+		return common.Address{}, ErrAddressChecksum
+	}
+	return result, nil
+}
+
+// checksum returns the checksummed address of the lowercase hex digits, the hash of EIP-1191 checksums is prefixed
+// with the chain id
+func checksum(lower, prefix string) string {
+	hash := crypto.Keccak256([]byte(prefix + lower))
+	result := []byte(lower)
+	for i, c := range result {
+		if c < 'a' {
+			continue
+		}
+		nibble := hash[i/2] >> 4
+		if i%2 == 1 {
+			nibble = hash[i/2] & 0xf
+		}
+		if nibble >= 8 {
+			result[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(result)
+}
@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+)
+
+// nolint funlen
+func TestParseAddress(t *testing.T) {
+	var tests = []struct {
+		Input  string
+		Mode   AddressMode
+		Output string
+		Err    error
+	}{
+		{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", Strict, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", nil},
+		{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", Lenient, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", nil},
+		{"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", Lenient, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", nil},
+		{"0X5AAEB6053F3E94C9B9A09F33669435E7EF1BEAED", Lenient, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", nil},
+		{"5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", Lenient, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", nil},
+		{"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", Strict, "", ErrAddressChecksum},
+		{"5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", Strict, "", ErrAddressPrefix},
+		{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD", Lenient, "", ErrAddressChecksum},
+		{"0x123", Lenient, "", ErrAddressLength},
+		{"0x", Lenient, "", ErrAddressLength},
+		{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed00", Lenient, "", ErrAddressLength},
+		{"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaeg", Lenient, "", ErrAddressHex},
+	}
+	for i, test := range tests {
+		output, err := ParseAddress(test.Input, test.Mode)
+		if err != test.Err {
+			t.Errorf("test #%d: expect[%+v], but got[%+v]", i, test.Err, err)
+			continue
+		}
+		if err == nil && output.Hex() != test.Output {
+			t.Errorf("test #%d: expect[%+v], but got[%+v]", i, test.Output, output.Hex())
+		}
+	}
+}
+
+func TestParseChainAddress(t *testing.T) {
+	// Test cases from https://github.com/ethereum/EIPs/blob/master/EIPS/eip-1191.md
+	var tests = []struct {
+		ChainID constants.ChainID
+		Address string
+	}{
+		{30, "0x5aaEB6053f3e94c9b9a09f33669435E7ef1bEAeD"},
+		{30, "0xFb6916095cA1Df60bb79ce92cE3EA74c37c5d359"},
+		{30, "0xDBF03B407c01E7CD3cBea99509D93F8Dddc8C6FB"},
+		{30, "0xD1220A0Cf47c7B9BE7a2e6ba89F429762E7B9adB"},
+		{31, "0x5aAeb6053F3e94c9b9A09F33669435E7EF1BEaEd"},
+		{31, "0xFb6916095CA1dF60bb79CE92ce3Ea74C37c5D359"},
+		{31, "0xdbF03B407C01E7cd3cbEa99509D93f8dDDc8C6fB"},
+	}
+	for i, test := range tests {
+		output, err := ParseChainAddress(test.Address, test.ChainID, Strict)
+		if err != nil {
+			t.Errorf("test #%d: %v", i, err)
+			continue
+		}
+		if checksummed := ChecksumAddress(output, test.ChainID); checksummed != test.Address {
+			t.Errorf("test #%d: expect[%+v], but got[%+v]", i, test.Address, checksummed)
+		}
+		// EIP-55 checksums are not valid on EIP-1191 chains
+		if _, err := ParseChainAddress(output.Hex(), test.ChainID, Strict); err != ErrAddressChecksum {
+			t.Errorf("test #%d: expect[%+v], but got[%+v]", i, ErrAddressChecksum, err)
+		}
+	}
+
+	// the other chains use EIP-55 checksums
+	address := common.HexToAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	if output := ChecksumAddress(address, constants.Mainnet); output != address.Hex() {
+		t.Errorf("expect[%+v], but got[%+v]", address.Hex(), output)
+	}
+	if _, err := ParseChainAddress(address.Hex(), constants.Mainnet, Strict); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMustParseAddress(t *testing.T) {
+	expect := "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+	if output := MustParseAddress("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed").Hex(); output != expect {
+		t.Errorf("expect[%+v], but got[%+v]", expect, output)
+	}
+
+	// panics for malformed addresses
+	for _, input := range []string{"0xa", "0x123", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: should panic", input)
+				}
+			}()
+			MustParseAddress(input)
+		}()
+	}
+}
@@ -17,7 +17,10 @@ func ValidateSolidityTypeInstance(value *big.Int, t constants.SolidityType) erro
 	return nil
 }
 
-// ValidateAndParseAddress warns if addresses are not checksummed
+// ValidateAndParseAddress parses the address as common.HexToAddress does, it never fails, so malformed addresses are
+// parsed leniently, e.g. "0xa" is the address 0x...0A.
+//
+// Deprecated: use ParseAddress to validate untrusted input, or MustParseAddress for address literals.
 func ValidateAndParseAddress(address string) common.Address {
 	return common.HexToAddress(address)
 }
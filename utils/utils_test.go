@@ -22,6 +22,8 @@ func TestValidateAndParseAddress(t *testing.T) {
 		{"0x0a", "0x000000000000000000000000000000000000000A"},
 		{"0x00a", "0x000000000000000000000000000000000000000A"},
 		{"0x000000000000000000000000000000000000000a", "0x000000000000000000000000000000000000000A"},
+		// a wrong checksum does not fail either
+		{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"},
 	}
 	for i, test := range tests {
 		output := ValidateAndParseAddress(test.Input)
@@ -0,0 +1,162 @@
+// Package v1 models Uniswap V1 exchanges, which pair ETHER with a single token, the quotes are expressed in
+// entities.TokenAmount so that they are directly comparable with the V2 Trades, see Migrator.
+package v1
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+	"github.com/miraclesu/uniswap-sdk-go/entities"
+)
+
+var (
+	// FactoryAddress the address of the V1 factory on mainnet, the exchange addresses are registered by the factory
+	// instead of being derived from the token address, see Exchange.Address
+	FactoryAddress = common.HexToAddress("0xc0a47dFe034B400B47bDaD5FecDa2621de6c4d95")
+
+	// the 0.3% fee of the exchanges
+	feeNumerator   = big.NewInt(997)
+	feeDenominator = big.NewInt(1000)
+)
+
+var (
+	// ErrNotEther the ETHER reserve of the exchange is not ETHER
+	ErrNotEther = fmt.Errorf("reserve is not ETHER")
+	// ErrInvalidToken the token reserve of the exchange is ETHER or WETH
+	ErrInvalidToken = fmt.Errorf("invalid exchange token")
+)
+
+// Exchange represents a Uniswap V1 exchange of ETHER and a token
+type Exchange struct {
+	Address common.Address
+	// the ETHER reserve, whose Token is at the WETH address, see entities.NewEtherAmount
+	EthReserve   *entities.TokenAmount
+	TokenReserve *entities.TokenAmount
+}
+
+// NewExchange creates an Exchange
+// @param address the address of the exchange, as returned by getExchange of the factory
+// @param ethReserve the ETHER balance of the exchange
+// @param tokenReserve the token balance of the exchange
+func NewExchange(address common.Address, ethReserve, tokenReserve *entities.TokenAmount) (*Exchange, error) {
+	if !ethReserve.Currency.Equals(entities.ETHER) {
+		return nil, ErrNotEther
+	}
+	if tokenReserve.Currency.IsNative() || ethReserve.Token.Equals(tokenReserve.Token) {
+		return nil, ErrInvalidToken
+	}
+	if ethReserve.Token.ChainID != tokenReserve.Token.ChainID {
+		return nil, entities.ErrDiffChainID
+	}
+	return &Exchange{
+		Address:      address,
+		EthReserve:   ethReserve,
+		TokenReserve: tokenReserve,
+	}, nil
+}
+
+// ChainID returns the chain ID of the exchange
+func (e *Exchange) ChainID() constants.ChainID {
+	return e.TokenReserve.Token.ChainID
+}
+
+// Token returns the token of the exchange
+func (e *Exchange) Token() *entities.Token {
+	return e.TokenReserve.Token
+}
+
+// GetEthToTokenInputPrice returns the amount of tokens bought for the ETHER sold
+// @param ethSold the amount of ETHER sold
+func (e *Exchange) GetEthToTokenInputPrice(ethSold *entities.TokenAmount) (*entities.TokenAmount, error) {
+	if !ethSold.Token.Equals(e.EthReserve.Token) {
+		return nil, entities.ErrDiffToken
+	}
+	return getInputPrice(ethSold, e.EthReserve, e.TokenReserve)
+}
+
+// GetEthToTokenOutputPrice returns the amount of ETHER sold to buy the tokens
+// @param tokensBought the amount of tokens bought
+func (e *Exchange) GetEthToTokenOutputPrice(tokensBought *entities.TokenAmount) (*entities.TokenAmount, error) {
+	if !tokensBought.Token.Equals(e.Token()) {
+		return nil, entities.ErrDiffToken
+	}
+	return getOutputPrice(tokensBought, e.EthReserve, e.TokenReserve)
+}
+
+// GetTokenToEthInputPrice returns the amount of ETHER bought for the tokens sold
+// @param tokensSold the amount of tokens sold
+func (e *Exchange) GetTokenToEthInputPrice(tokensSold *entities.TokenAmount) (*entities.TokenAmount, error) {
+	if !tokensSold.Token.Equals(e.Token()) {
+		return nil, entities.ErrDiffToken
+	}
+	return getInputPrice(tokensSold, e.TokenReserve, e.EthReserve)
+}
+
+// GetTokenToEthOutputPrice returns the amount of tokens sold to buy the ETHER
+// @param ethBought the amount of ETHER bought
+func (e *Exchange) GetTokenToEthOutputPrice(ethBought *entities.TokenAmount) (*entities.TokenAmount, error) {
+	if !ethBought.Token.Equals(e.EthReserve.Token) {
+		return nil, entities.ErrDiffToken
+	}
+	return getOutputPrice(ethBought, e.TokenReserve, e.EthReserve)
+}
+
+// GetTokenToTokenInputPrice returns the amount of the output exchange tokens bought for the tokens sold, the tokens
+// are sold for ETHER which buys the output tokens as tokenToTokenSwapInput does
+// @param tokensSold the amount of tokens sold
+// @param output the exchange of the tokens bought
+func (e *Exchange) GetTokenToTokenInputPrice(tokensSold *entities.TokenAmount, output *Exchange) (*entities.TokenAmount, error) {
+	ethBought, err := e.GetTokenToEthInputPrice(tokensSold)
+	if err != nil {
+		return nil, err
+	}
+	return output.GetEthToTokenInputPrice(ethBought)
+}
+
+// GetTokenToTokenOutputPrice returns the amount of tokens sold to buy the output exchange tokens, as
+// tokenToTokenSwapOutput does
+// @param tokensBought the amount of tokens bought
+// @param output the exchange of the tokens bought
+func (e *Exchange) GetTokenToTokenOutputPrice(tokensBought *entities.TokenAmount, output *Exchange) (*entities.TokenAmount, error) {
+	ethSold, err := output.GetEthToTokenOutputPrice(tokensBought)
+	if err != nil {
+		return nil, err
+	}
+	return e.GetTokenToEthOutputPrice(ethSold)
+}
+
+// getInputPrice returns the output amount for the input amount as getInputPrice of the exchange contract
+func getInputPrice(inputAmount, inputReserve, outputReserve *entities.TokenAmount) (*entities.TokenAmount, error) {
+	if inputReserve.Raw().Sign() == 0 || outputReserve.Raw().Sign() == 0 {
+		return nil, entities.ErrInsufficientReserves
+	}
+	inputAmountWithFee := big.NewInt(0).Mul(inputAmount.Raw(), feeNumerator)
+	numerator := big.NewInt(0).Mul(inputAmountWithFee, outputReserve.Raw())
+	denominator := big.NewInt(0).Mul(inputReserve.Raw(), feeDenominator)
+	denominator.Add(denominator, inputAmountWithFee)
+	outputAmount, err := entities.NewTokenAmount(outputReserve.Token, numerator.Div(numerator, denominator))
+	if err != nil {
+		return nil, err
+	}
+	if outputAmount.Raw().Sign() == 0 {
+		return nil, entities.ErrInsufficientInputAmount
+	}
+	return outputAmount, nil
+}
+
+// getOutputPrice returns the input amount for the output amount as getOutputPrice of the exchange contract
+func getOutputPrice(outputAmount, inputReserve, outputReserve *entities.TokenAmount) (*entities.TokenAmount, error) {
+	if inputReserve.Raw().Sign() == 0 || outputReserve.Raw().Sign() == 0 ||
+		outputAmount.Raw().Cmp(outputReserve.Raw()) >= 0 {
+		return nil, entities.ErrInsufficientReserves
+	}
+	numerator := big.NewInt(0).Mul(inputReserve.Raw(), outputAmount.Raw())
+	numerator.Mul(numerator, feeDenominator)
+	denominator := big.NewInt(0).Sub(outputReserve.Raw(), outputAmount.Raw())
+	denominator.Mul(denominator, feeNumerator)
+	numerator.Div(numerator, denominator)
+	return entities.NewTokenAmount(inputReserve.Token, numerator.Add(numerator, constants.One))
+}
@@ -0,0 +1,112 @@
+package v1
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+	"github.com/miraclesu/uniswap-sdk-go/entities"
+)
+
+var (
+	USDC, _ = entities.NewToken(constants.Mainnet, common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"), 6, "USDC", "USD Coin")
+	DAI, _  = entities.NewToken(constants.Mainnet, common.HexToAddress("0x6B175474E89094C44Da98b954EedeAC495271d0F"), 18, "DAI", "DAI Stablecoin")
+	ETH, _  = entities.ETHER.Unwrapped(constants.Mainnet)
+	WETH    = entities.WETH[constants.Mainnet]
+)
+
+func mustTokenAmount(t *testing.T, token *entities.Token, amount string) *entities.TokenAmount {
+	tokenAmount, err := entities.ParseTokenAmount(token, amount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tokenAmount
+}
+
+func mustExchange(t *testing.T, ethReserve, tokenReserve *entities.TokenAmount) *Exchange {
+	exchange, err := NewExchange(common.Address{}, ethReserve, tokenReserve)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return exchange
+}
+
+func TestNewExchange(t *testing.T) {
+	tests := []struct {
+		Name         string
+		EthReserve   *entities.TokenAmount
+		TokenReserve *entities.TokenAmount
+		Expect       error
+	}{
+		{"ETHER and token", mustTokenAmount(t, ETH, "1"), mustTokenAmount(t, DAI, "1"), nil},
+		{"WETH and token", mustTokenAmount(t, WETH, "1"), mustTokenAmount(t, DAI, "1"), ErrNotEther},
+		{"ETHER and WETH", mustTokenAmount(t, ETH, "1"), mustTokenAmount(t, WETH, "1"), ErrInvalidToken},
+		{"ETHER and ETHER", mustTokenAmount(t, ETH, "1"), mustTokenAmount(t, ETH, "1"), ErrInvalidToken},
+	}
+	for _, test := range tests {
+		if _, err := NewExchange(common.Address{}, test.EthReserve, test.TokenReserve); err != test.Expect {
+			t.Errorf("%s: expect[%+v], but got[%+v]", test.Name, test.Expect, err)
+		}
+	}
+}
+
+// nolint funlen
+func TestExchangePrices(t *testing.T) {
+	daiExchange := mustExchange(t, mustTokenAmount(t, ETH, "10"), mustTokenAmount(t, DAI, "5000"))
+	usdcExchange := mustExchange(t, mustTokenAmount(t, ETH, "20"), mustTokenAmount(t, USDC, "10000"))
+
+	tests := []struct {
+		Name   string
+		Quote  func() (*entities.TokenAmount, error)
+		Token  *entities.Token
+		Expect string
+	}{
+		{"ETH -> DAI exact input", func() (*entities.TokenAmount, error) {
+			return daiExchange.GetEthToTokenInputPrice(mustTokenAmount(t, ETH, "1"))
+		}, DAI, "453305446940074565790"},
+		{"WETH -> DAI exact input", func() (*entities.TokenAmount, error) {
+			return daiExchange.GetEthToTokenInputPrice(mustTokenAmount(t, WETH, "1"))
+		}, DAI, "453305446940074565790"},
+		{"ETH -> DAI exact output", func() (*entities.TokenAmount, error) {
+			return daiExchange.GetEthToTokenOutputPrice(mustTokenAmount(t, DAI, "100"))
+		}, ETH, "204695719812498721"},
+		{"DAI -> ETH exact input", func() (*entities.TokenAmount, error) {
+			return daiExchange.GetTokenToEthInputPrice(mustTokenAmount(t, DAI, "100"))
+		}, ETH, "195501696178206561"},
+		{"DAI -> ETH exact output", func() (*entities.TokenAmount, error) {
+			return daiExchange.GetTokenToEthOutputPrice(mustTokenAmount(t, ETH, "1"))
+		}, DAI, "557227237267357628441"},
+		{"DAI -> USDC exact input", func() (*entities.TokenAmount, error) {
+			return daiExchange.GetTokenToTokenInputPrice(mustTokenAmount(t, DAI, "100"), usdcExchange)
+		}, USDC, "96516964"},
+		{"DAI -> USDC exact output", func() (*entities.TokenAmount, error) {
+			return daiExchange.GetTokenToTokenOutputPrice(mustTokenAmount(t, USDC, "100"), usdcExchange)
+		}, DAI, "103720569897649890194"},
+	}
+	for _, test := range tests {
+		output, err := test.Quote()
+		if err != nil {
+			t.Fatalf("%s: %v", test.Name, err)
+		}
+		if !output.Token.Equals(test.Token) || output.Raw().String() != test.Expect {
+			t.Errorf("%s: expect[%+v], but got[%+v]", test.Name, test.Expect, output.Raw())
+		}
+	}
+
+	if _, err := daiExchange.GetEthToTokenInputPrice(mustTokenAmount(t, DAI, "1")); err != entities.ErrDiffToken {
+		t.Errorf("expect[%+v], but got[%+v]", entities.ErrDiffToken, err)
+	}
+	if _, err := daiExchange.GetEthToTokenOutputPrice(mustTokenAmount(t, DAI, "5000")); err != entities.ErrInsufficientReserves {
+		t.Errorf("expect[%+v], but got[%+v]", entities.ErrInsufficientReserves, err)
+	}
+	dust, _ := entities.NewTokenAmount(DAI, big.NewInt(1))
+	if _, err := daiExchange.GetTokenToEthInputPrice(dust); err != entities.ErrInsufficientInputAmount {
+		t.Errorf("expect[%+v], but got[%+v]", entities.ErrInsufficientInputAmount, err)
+	}
+	empty := mustExchange(t, mustTokenAmount(t, ETH, "0"), mustTokenAmount(t, DAI, "0"))
+	if _, err := empty.GetEthToTokenInputPrice(mustTokenAmount(t, ETH, "1")); err != entities.ErrInsufficientReserves {
+		t.Errorf("expect[%+v], but got[%+v]", entities.ErrInsufficientReserves, err)
+	}
+}
@@ -0,0 +1,148 @@
+package v1
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+	"github.com/miraclesu/uniswap-sdk-go/entities"
+)
+
+var (
+	// ErrNoExchange there is no V1 exchange of the token
+	ErrNoExchange = fmt.Errorf("no exchange")
+)
+
+// Migrator quotes the V2 trades on the V1 exchanges, so that the trades may be routed to the better venue
+type Migrator struct {
+	lk *sync.RWMutex
+	// chain ID : token address : exchange
+	exchanges map[constants.ChainID]map[common.Address]*Exchange
+}
+
+// NewMigrator creates a Migrator of the exchanges
+func NewMigrator(exchanges ...*Exchange) *Migrator {
+	m := &Migrator{
+		lk:        &sync.RWMutex{},
+		exchanges: make(map[constants.ChainID]map[common.Address]*Exchange),
+	}
+	for _, exchange := range exchanges {
+		m.Add(exchange)
+	}
+	return m
+}
+
+// Add adds the exchange, replacing the previous exchange of the token e.g. to update the reserves
+func (m *Migrator) Add(exchange *Exchange) {
+	m.lk.Lock()
+	defer m.lk.Unlock()
+
+	exchanges, ok := m.exchanges[exchange.ChainID()]
+	if !ok {
+		exchanges = make(map[common.Address]*Exchange)
+		m.exchanges[exchange.ChainID()] = exchanges
+	}
+	exchanges[exchange.Token().Address] = exchange
+}
+
+// Exchange returns the exchange of the token
+func (m *Migrator) Exchange(token *entities.Token) (*Exchange, error) {
+	m.lk.RLock()
+	defer m.lk.RUnlock()
+
+	exchange, ok := m.exchanges[token.ChainID][token.Address]
+	if !ok {
+		return nil, ErrNoExchange
+	}
+	return exchange, nil
+}
+
+// Comparison the V1 quote of a V2 trade
+type Comparison struct {
+	Trade *entities.Trade
+	// the V1 output amount of the trade input amount for exact input trades, or the V1 input amount of the trade
+	// output amount for exact output trades, in the currency of the trade
+	Quote *entities.TokenAmount
+}
+
+// V1Better returns true if V1 gives more output for exact input trades, or takes less input for exact output trades
+func (c *Comparison) V1Better() bool {
+	if c.Trade.TradeType == constants.ExactInput {
+		return c.Quote.Raw().Cmp(c.Trade.OutputAmount().Raw()) > 0
+	}
+	return c.Quote.Raw().Cmp(c.Trade.InputAmount().Raw()) < 0
+}
+
+// Compare quotes the V2 trade on the V1 exchanges, the ETHER or WETH of the trade, i.e. entities.WETH, is swapped as
+// ETHER on the exchange of the other token, and tokens are swapped for tokens through ETHER on both exchanges
+// @param trade the V2 trade, whose tokens have exchanges
+func (m *Migrator) Compare(trade *entities.Trade) (*Comparison, error) {
+	inputAmount, outputAmount := trade.InputAmount(), trade.OutputAmount()
+	weth, ok := entities.WETH[inputAmount.Token.ChainID]
+	if !ok {
+		return nil, entities.ErrNoWETH
+	}
+	// the V1 exchanges of the tokens, nil for ETHER and WETH
+	var input, output *Exchange
+	var err error
+	if !inputAmount.Token.Equals(weth) {
+		if input, err = m.Exchange(inputAmount.Token); err != nil {
+			return nil, err
+		}
+	}
+	if !outputAmount.Token.Equals(weth) {
+		if output, err = m.Exchange(outputAmount.Token); err != nil {
+			return nil, err
+		}
+	}
+
+	var quote *entities.TokenAmount
+	if trade.TradeType == constants.ExactInput {
+		quote, err = quoteExactIn(input, output, inputAmount)
+		if err == nil {
+			quote, err = entities.NewTokenAmount(outputAmount.Token, quote.Raw())
+		}
+	} else {
+		quote, err = quoteExactOut(input, output, outputAmount)
+		if err == nil {
+			quote, err = entities.NewTokenAmount(inputAmount.Token, quote.Raw())
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Comparison{
+		Trade: trade,
+		Quote: quote,
+	}, nil
+}
+
+// quoteExactIn returns the V1 output amount for the input amount, the exchanges are nil for ETHER
+func quoteExactIn(input, output *Exchange, inputAmount *entities.TokenAmount) (*entities.TokenAmount, error) {
+	switch {
+	case input == nil && output == nil:
+		return nil, entities.ErrInvalidPath
+	case input == nil:
+		return output.GetEthToTokenInputPrice(inputAmount)
+	case output == nil:
+		return input.GetTokenToEthInputPrice(inputAmount)
+	default:
+		return input.GetTokenToTokenInputPrice(inputAmount, output)
+	}
+}
+
+// quoteExactOut returns the V1 input amount for the output amount, the exchanges are nil for ETHER
+func quoteExactOut(input, output *Exchange, outputAmount *entities.TokenAmount) (*entities.TokenAmount, error) {
+	switch {
+	case input == nil && output == nil:
+		return nil, entities.ErrInvalidPath
+	case input == nil:
+		return output.GetEthToTokenOutputPrice(outputAmount)
+	case output == nil:
+		return input.GetTokenToEthOutputPrice(outputAmount)
+	default:
+		return input.GetTokenToTokenOutputPrice(outputAmount, output)
+	}
+}
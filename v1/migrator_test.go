@@ -0,0 +1,88 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+	"github.com/miraclesu/uniswap-sdk-go/entities"
+)
+
+func mustPair(t *testing.T, tokenAmountA, tokenAmountB *entities.TokenAmount) *entities.Pair {
+	pair, err := entities.NewPair(tokenAmountA, tokenAmountB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pair
+}
+
+func mustTrade(t *testing.T, pairs []*entities.Pair, input, output entities.Asset, amount *entities.TokenAmount, tradeType constants.TradeType) *entities.Trade {
+	route, err := entities.NewRoute(pairs, input, output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trade, err := entities.NewTrade(route, amount, tradeType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return trade
+}
+
+// nolint funlen
+func TestMigratorCompare(t *testing.T) {
+	daiExchange := mustExchange(t, mustTokenAmount(t, ETH, "10"), mustTokenAmount(t, DAI, "5000"))
+	usdcExchange := mustExchange(t, mustTokenAmount(t, ETH, "20"), mustTokenAmount(t, USDC, "10000"))
+	migrator := NewMigrator(daiExchange, usdcExchange)
+
+	deepPair := mustPair(t, mustTokenAmount(t, WETH, "20"), mustTokenAmount(t, DAI, "10000"))
+	shallowPair := mustPair(t, mustTokenAmount(t, WETH, "2"), mustTokenAmount(t, DAI, "1000"))
+	usdcPair := mustPair(t, mustTokenAmount(t, WETH, "20"), mustTokenAmount(t, USDC, "10000"))
+
+	tests := []struct {
+		Name     string
+		Trade    *entities.Trade
+		Expect   string
+		V1Better bool
+	}{
+		{"ETH -> DAI deep V2", mustTrade(t, []*entities.Pair{deepPair}, entities.ETHER, DAI,
+			mustTokenAmount(t, ETH, "1"), constants.ExactInput), "453305446940074565790", false},
+		{"ETH -> DAI shallow V2", mustTrade(t, []*entities.Pair{shallowPair}, entities.ETHER, DAI,
+			mustTokenAmount(t, ETH, "1"), constants.ExactInput), "453305446940074565790", true},
+		{"WETH -> DAI shallow V2", mustTrade(t, []*entities.Pair{shallowPair}, WETH, DAI,
+			mustTokenAmount(t, WETH, "1"), constants.ExactInput), "453305446940074565790", true},
+		{"DAI -> ETH exact output", mustTrade(t, []*entities.Pair{deepPair}, DAI, entities.ETHER,
+			mustTokenAmount(t, ETH, "1"), constants.ExactOutput), "557227237267357628441", false},
+		{"DAI -> USDC exact input", mustTrade(t, []*entities.Pair{deepPair, usdcPair}, DAI, USDC,
+			mustTokenAmount(t, DAI, "100"), constants.ExactInput), "96516964", false},
+		{"DAI -> USDC exact output", mustTrade(t, []*entities.Pair{shallowPair, usdcPair}, DAI, USDC,
+			mustTokenAmount(t, USDC, "100"), constants.ExactOutput), "103720569897649890194", true},
+	}
+	for _, test := range tests {
+		comparison, err := migrator.Compare(test.Trade)
+		if err != nil {
+			t.Fatalf("%s: %v", test.Name, err)
+		}
+		if output := comparison.Quote.Raw().String(); output != test.Expect {
+			t.Errorf("%s: expect[%+v], but got[%+v]", test.Name, test.Expect, output)
+		}
+		// the quote is in the currency of the trade
+		currency := test.Trade.OutputAmount().Currency
+		if test.Trade.TradeType == constants.ExactOutput {
+			currency = test.Trade.InputAmount().Currency
+		}
+		if !comparison.Quote.Currency.Equals(currency) {
+			t.Errorf("%s: expect[%+v], but got[%+v]", test.Name, currency, comparison.Quote.Currency)
+		}
+		if output := comparison.V1Better(); output != test.V1Better {
+			t.Errorf("%s: expect[%+v], but got[%+v]", test.Name, test.V1Better, output)
+		}
+	}
+
+	// the tokens must have exchanges
+	{
+		trade := mustTrade(t, []*entities.Pair{usdcPair}, entities.ETHER, USDC,
+			mustTokenAmount(t, ETH, "1"), constants.ExactInput)
+		if _, err := NewMigrator(daiExchange).Compare(trade); err != ErrNoExchange {
+			t.Errorf("expect[%+v], but got[%+v]", ErrNoExchange, err)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Package v3 models Uniswap V3 concentrated liquidity pools, the quotes are expressed in entities.TokenAmount so
+// that they are directly comparable with the V2 Trades.
+package v3
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+// FeeAmount the fee of a pool in hundredths of a bip, i.e. 1e-6
+type FeeAmount int
+
+// the fee amounts enabled by the factory
+const (
+	FeeLowest FeeAmount = 100
+	FeeLow    FeeAmount = 500
+	FeeMedium FeeAmount = 3000
+	FeeHigh   FeeAmount = 10000
+)
+
+var (
+	// TickSpacings the tick spacing of the fee amounts
+	TickSpacings = map[FeeAmount]int{
+		FeeLowest: 1,
+		FeeLow:    10,
+		FeeMedium: 60,
+		FeeHigh:   200,
+	}
+)
+
+// TickSpacing returns the tick spacing of the fee amount, 0 if it is not enabled
+func (f FeeAmount) TickSpacing() int {
+	return TickSpacings[f]
+}
+
+const (
+	// MinTick the minimum tick that may be passed to GetSqrtRatioAtTick, computed from log base 1.0001 of 2**-128
+	MinTick = -887272
+	// MaxTick the maximum tick that may be passed to GetSqrtRatioAtTick, computed from log base 1.0001 of 2**128
+	MaxTick = -MinTick
+)
+
+var (
+	// FactoryAddress the address of the Uniswap V3 factory on Ethereum and most of the other chains
+	FactoryAddress = common.HexToAddress("0x1F98431c8aD98523631AE4a59f267346ea31F984")
+	// PoolInitCodeHash the init code hash of the Uniswap V3 pools
+	PoolInitCodeHash = common.FromHex("0xe34f199b19b2b4f47f68442619d555527d244f78a3297ea89325f843f87b8b54")
+
+	// MinSqrtRatio the sqrt ratio of MinTick
+	MinSqrtRatio = big.NewInt(4295128739)
+	// MaxSqrtRatio the sqrt ratio of MaxTick
+	MaxSqrtRatio, _ = new(big.Int).SetString("1461446703485210103287273052203988822378723970342", 10)
+
+	// Q96 2**96, the denominator of the sqrt prices
+	Q96 = new(big.Int).Lsh(big.NewInt(1), 96)
+	// Q192 2**192, the denominator of the squared sqrt prices
+	Q192 = new(big.Int).Lsh(big.NewInt(1), 192)
+
+	maxUint128 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+	maxUint160 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 160), big.NewInt(1))
+	maxUint256 = math.MaxBig256
+	// the denominator of the fees
+	feeDenominator = big.NewInt(1000000)
+)
+
+var (
+	// ErrInvalidFee the fee amount is not enabled
+	ErrInvalidFee = fmt.Errorf("invalid fee amount")
+	// ErrInvalidTick the tick is out of [MinTick, MaxTick] or is not a multiple of the tick spacing
+	ErrInvalidTick = fmt.Errorf("invalid tick")
+	// ErrInvalidSqrtRatio the sqrt ratio is out of [MinSqrtRatio, MaxSqrtRatio), or out of the current tick
+	ErrInvalidSqrtRatio = fmt.Errorf("invalid sqrt ratio")
+	// ErrInvalidTicks the ticks are not sorted, or their net liquidity does not sum to 0
+	ErrInvalidTicks = fmt.Errorf("invalid ticks")
+	// ErrInvalidLiquidity the liquidity is negative or overflows
+	ErrInvalidLiquidity = fmt.Errorf("invalid liquidity")
+	// ErrInvalidPriceLimit the price limit is out of bounds or on the wrong side of the current price
+	ErrInvalidPriceLimit = fmt.Errorf("invalid sqrt price limit")
+	// ErrInsufficientLiquidity the pool does not have enough liquidity to fill the swap
+	ErrInsufficientLiquidity = fmt.Errorf("insufficient liquidity")
+	// ErrOverflow the result does not fit the solidity type
+	ErrOverflow = fmt.Errorf("overflow")
+)
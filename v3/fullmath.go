@@ -0,0 +1,33 @@
+package v3
+
+import (
+	"math/big"
+)
+
+// mulDivRoundingUp returns ceil(a * b / denominator)
+func mulDivRoundingUp(a, b, denominator *big.Int) *big.Int {
+	product := new(big.Int).Mul(a, b)
+	result, remainder := new(big.Int).QuoRem(product, denominator, new(big.Int))
+	if remainder.Sign() != 0 {
+		result.Add(result, big.NewInt(1))
+	}
+	return result
+}
+
+// addDelta adds the signed liquidity delta to the liquidity
+func addDelta(x, y *big.Int) (*big.Int, error) {
+	result := new(big.Int).Add(x, y)
+	if result.Sign() < 0 || result.Cmp(maxUint128) > 0 {
+		return nil, ErrInvalidLiquidity
+	}
+	return result, nil
+}
+
+// EncodeSqrtRatioX96 returns the sqrt ratio as a Q64.96 corresponding to the given ratio of amount1 and amount0
+// @param amount1 the numerator amount, i.e. the amount of token1
+// @param amount0 the denominator amount, i.e. the amount of token0
+func EncodeSqrtRatioX96(amount1, amount0 *big.Int) *big.Int {
+	ratioX192 := new(big.Int).Lsh(amount1, 192)
+	ratioX192.Quo(ratioX192, amount0)
+	return ratioX192.Sqrt(ratioX192)
+}
@@ -0,0 +1,363 @@
+package v3
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+	"github.com/miraclesu/uniswap-sdk-go/entities"
+)
+
+var _ entities.Pool = (*Pool)(nil)
+
+// Pool represents a Uniswap V3 pool
+type Pool struct {
+	// sorted tokens
+	token0 *entities.Token
+	token1 *entities.Token
+	Fee    FeeAmount
+	// the current sqrt price as a Q64.96
+	SqrtRatioX96 *big.Int
+	// the liquidity in range
+	Liquidity   *big.Int
+	TickCurrent int
+	// the initialized ticks
+	Ticks *TickList
+}
+
+// NewPool creates a Pool
+// @param tokenA one of the tokens in the pool
+// @param tokenB the other token in the pool
+// @param fee the fee of the pool
+// @param sqrtRatioX96 the sqrt of the current ratio of amounts of token1 to token0
+// @param liquidity the current value of in range liquidity
+// @param tickCurrent the current tick of the pool
+// @param ticks the initialized ticks of the pool, sorted by index, which may be shared by several pools
+func NewPool(tokenA, tokenB *entities.Token, fee FeeAmount, sqrtRatioX96, liquidity *big.Int, tickCurrent int, ticks []*Tick) (*Pool, error) {
+	if fee.TickSpacing() == 0 {
+		return nil, ErrInvalidFee
+	}
+	tickList, err := NewTickList(ticks, fee.TickSpacing())
+	if err != nil {
+		return nil, err
+	}
+	return newPool(tokenA, tokenB, fee, sqrtRatioX96, liquidity, tickCurrent, tickList)
+}
+
+func newPool(tokenA, tokenB *entities.Token, fee FeeAmount, sqrtRatioX96, liquidity *big.Int, tickCurrent int, ticks *TickList) (*Pool, error) {
+	if fee.TickSpacing() == 0 {
+		return nil, ErrInvalidFee
+	}
+	if liquidity.Sign() < 0 || liquidity.Cmp(maxUint128) > 0 {
+		return nil, ErrInvalidLiquidity
+	}
+	if tickCurrent < MinTick || tickCurrent >= MaxTick {
+		return nil, ErrInvalidTick
+	}
+	// the price must be within the current tick
+	tickCurrentSqrtRatioX96, err := GetSqrtRatioAtTick(tickCurrent)
+	if err != nil {
+		return nil, err
+	}
+	nextTickSqrtRatioX96, err := GetSqrtRatioAtTick(tickCurrent + 1)
+	if err != nil {
+		return nil, err
+	}
+	if sqrtRatioX96.Cmp(tickCurrentSqrtRatioX96) < 0 || sqrtRatioX96.Cmp(nextTickSqrtRatioX96) > 0 {
+		return nil, ErrInvalidSqrtRatio
+	}
+
+	ok, err := tokenA.SortsBefore(tokenB)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		tokenA, tokenB = tokenB, tokenA
+	}
+	return &Pool{
+		token0:       tokenA,
+		token1:       tokenB,
+		Fee:          fee,
+		SqrtRatioX96: sqrtRatioX96,
+		Liquidity:    liquidity,
+		TickCurrent:  tickCurrent,
+		Ticks:        ticks,
+	}, nil
+}
+
+// GetPoolAddress returns the address of the pool of the tokens and fee deployed by the factory
+// @param factory the factory address, e.g. FactoryAddress
+// @param tokenA one of the tokens of the pool
+// @param tokenB the other token of the pool
+// @param fee the fee of the pool
+func GetPoolAddress(factory common.Address, tokenA, tokenB *entities.Token, fee FeeAmount) (common.Address, error) {
+	ok, err := tokenA.SortsBefore(tokenB)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if !ok {
+		tokenA, tokenB = tokenB, tokenA
+	}
+
+	// abi.encode(token0, token1, fee)
+	var salt [32]byte
+	copy(salt[:], crypto.Keccak256(
+		common.LeftPadBytes(tokenA.Address.Bytes(), 32),
+		common.LeftPadBytes(tokenB.Address.Bytes(), 32),
+		common.LeftPadBytes(big.NewInt(int64(fee)).Bytes(), 32),
+	))
+	return crypto.CreateAddress2(factory, salt, PoolInitCodeHash), nil
+}
+
+// Token0 returns the first token in the pool
+func (p *Pool) Token0() *entities.Token {
+	return p.token0
+}
+
+// Token1 returns the last token in the pool
+func (p *Pool) Token1() *entities.Token {
+	return p.token1
+}
+
+// ChainID returns the chain ID of the tokens in the pool
+func (p *Pool) ChainID() constants.ChainID {
+	return p.token0.ChainID
+}
+
+// TickSpacing returns the tick spacing of the pool
+func (p *Pool) TickSpacing() int {
+	return p.Fee.TickSpacing()
+}
+
+// InvolvesToken returns true if the token is either token0 or token1
+// @param token to check
+func (p *Pool) InvolvesToken(token *entities.Token) bool {
+	return token.Equals(p.token0) || token.Equals(p.token1)
+}
+
+// Token0Price returns the current mid price of the pool in terms of token0, i.e. the ratio of token1 over token0
+func (p *Pool) Token0Price() *entities.Price {
+	return entities.NewPrice(p.token0.Currency, p.token1.Currency, Q192,
+		new(big.Int).Mul(p.SqrtRatioX96, p.SqrtRatioX96))
+}
+
+// Token1Price returns the current mid price of the pool in terms of token1, i.e. the ratio of token0 over token1
+func (p *Pool) Token1Price() *entities.Price {
+	return entities.NewPrice(p.token1.Currency, p.token0.Currency,
+		new(big.Int).Mul(p.SqrtRatioX96, p.SqrtRatioX96), Q192)
+}
+
+// PriceOf returns the price of the given token in terms of the other token in the pool
+// @param token token to return price of
+func (p *Pool) PriceOf(token *entities.Token) (*entities.Price, error) {
+	if !p.InvolvesToken(token) {
+		return nil, entities.ErrDiffToken
+	}
+	if token.Equals(p.token0) {
+		return p.Token0Price(), nil
+	}
+	return p.Token1Price(), nil
+}
+
+// GetOutputAmount returns the output amount of swapping the input amount and the pool after the swap
+// @param inputAmount the input amount for which to quote the output amount
+// @param sqrtPriceLimitX96 the Q64.96 sqrt price limit, nil for no limit, the swap stops at the limit and the input
+// amount is not fully swapped
+func (p *Pool) GetOutputAmount(inputAmount *entities.TokenAmount, sqrtPriceLimitX96 *big.Int) (*entities.TokenAmount, *Pool, error) {
+	if !p.InvolvesToken(inputAmount.Token) {
+		return nil, nil, entities.ErrDiffToken
+	}
+
+	zeroForOne := inputAmount.Token.Equals(p.token0)
+	result, err := p.swap(zeroForOne, inputAmount.Raw(), sqrtPriceLimitX96)
+	if err != nil {
+		return nil, nil, err
+	}
+	outputToken := p.token0
+	if zeroForOne {
+		outputToken = p.token1
+	}
+	outputAmount, err := entities.NewTokenAmount(outputToken, result.amountCalculated.Neg(result.amountCalculated))
+	if err != nil {
+		return nil, nil, err
+	}
+	if outputAmount.Raw().Sign() == 0 {
+		return nil, nil, entities.ErrInsufficientInputAmount
+	}
+	pool, err := newPool(p.token0, p.token1, p.Fee, result.sqrtRatioX96, result.liquidity, result.tickCurrent, p.Ticks)
+	if err != nil {
+		return nil, nil, err
+	}
+	return outputAmount, pool, nil
+}
+
+// GetInputAmount returns the input amount required to receive the output amount and the pool after the swap
+// @param outputAmount the output amount for which to quote the input amount
+// @param sqrtPriceLimitX96 the Q64.96 sqrt price limit, nil for no limit, the swap stops at the limit and the output
+// amount is not fully received
+func (p *Pool) GetInputAmount(outputAmount *entities.TokenAmount, sqrtPriceLimitX96 *big.Int) (*entities.TokenAmount, *Pool, error) {
+	if !p.InvolvesToken(outputAmount.Token) {
+		return nil, nil, entities.ErrDiffToken
+	}
+
+	zeroForOne := outputAmount.Token.Equals(p.token1)
+	result, err := p.swap(zeroForOne, new(big.Int).Neg(outputAmount.Raw()), sqrtPriceLimitX96)
+	if err != nil {
+		return nil, nil, err
+	}
+	inputToken := p.token1
+	if zeroForOne {
+		inputToken = p.token0
+	}
+	inputAmount, err := entities.NewTokenAmount(inputToken, result.amountCalculated)
+	if err != nil {
+		return nil, nil, err
+	}
+	pool, err := newPool(p.token0, p.token1, p.Fee, result.sqrtRatioX96, result.liquidity, result.tickCurrent, p.Ticks)
+	if err != nil {
+		return nil, nil, err
+	}
+	return inputAmount, pool, nil
+}
+
+// SwapExactIn implements entities.Pool, it is GetOutputAmount without a price limit
+func (p *Pool) SwapExactIn(inputAmount *entities.TokenAmount) (*entities.TokenAmount, entities.Pool, error) {
+	outputAmount, pool, err := p.GetOutputAmount(inputAmount, nil)
+	if err != nil {
+		return nil, nil, poolError(err)
+	}
+	return outputAmount, pool, nil
+}
+
+// SwapExactOut implements entities.Pool, it is GetInputAmount without a price limit
+func (p *Pool) SwapExactOut(outputAmount *entities.TokenAmount) (*entities.TokenAmount, entities.Pool, error) {
+	inputAmount, pool, err := p.GetInputAmount(outputAmount, nil)
+	if err != nil {
+		return nil, nil, poolError(err)
+	}
+	return inputAmount, pool, nil
+}
+
+// poolError returns the entities error of an insufficient liquidity error, so routing skips the pool
+func poolError(err error) error {
+	if err == ErrInsufficientLiquidity {
+		return entities.ErrInsufficientReserves
+	}
+	return err
+}
+
+type swapResult struct {
+	// the output amount, negative, for exact input swaps, and the input amount for exact output swaps
+	amountCalculated *big.Int
+	sqrtRatioX96     *big.Int
+	liquidity        *big.Int
+	tickCurrent      int
+}
+
+// swap executes a swap as the pool contract does
+// @param zeroForOne whether the amount in is token0 or token1
+// @param amountSpecified the amount of the swap, which implicitly configures the swap as exact input (positive), or
+// exact output (negative)
+// @param sqrtPriceLimitX96 the Q64.96 sqrt price limit, nil for no limit
+// nolint gocyclo
+func (p *Pool) swap(zeroForOne bool, amountSpecified, sqrtPriceLimitX96 *big.Int) (*swapResult, error) {
+	limited := sqrtPriceLimitX96 != nil
+	if !limited {
+		if zeroForOne {
+			sqrtPriceLimitX96 = new(big.Int).Add(MinSqrtRatio, big.NewInt(1))
+		} else {
+			sqrtPriceLimitX96 = new(big.Int).Sub(MaxSqrtRatio, big.NewInt(1))
+		}
+	}
+	if zeroForOne {
+		if sqrtPriceLimitX96.Cmp(MinSqrtRatio) <= 0 || sqrtPriceLimitX96.Cmp(p.SqrtRatioX96) >= 0 {
+			return nil, ErrInvalidPriceLimit
+		}
+	} else {
+		if sqrtPriceLimitX96.Cmp(MaxSqrtRatio) >= 0 || sqrtPriceLimitX96.Cmp(p.SqrtRatioX96) <= 0 {
+			return nil, ErrInvalidPriceLimit
+		}
+	}
+
+	exactInput := amountSpecified.Sign() >= 0
+	remaining := new(big.Int).Set(amountSpecified)
+	state := &swapResult{
+		amountCalculated: new(big.Int),
+		sqrtRatioX96:     p.SqrtRatioX96,
+		liquidity:        p.Liquidity,
+		tickCurrent:      p.TickCurrent,
+	}
+
+	// continue swapping as long as we haven't used the entire input/output and haven't reached the price limit
+	for remaining.Sign() != 0 && state.sqrtRatioX96.Cmp(sqrtPriceLimitX96) != 0 {
+		sqrtPriceStartX96 := state.sqrtRatioX96
+		tickNext, initialized := p.Ticks.nextInitializedTickWithinOneWord(state.tickCurrent, zeroForOne)
+		// ensure that we do not overshoot the min/max tick, as the tick bitmap is not aware of these bounds
+		if tickNext < MinTick {
+			tickNext = MinTick
+		} else if tickNext > MaxTick {
+			tickNext = MaxTick
+		}
+		sqrtPriceNextX96, err := GetSqrtRatioAtTick(tickNext)
+		if err != nil {
+			return nil, err
+		}
+
+		target := sqrtPriceNextX96
+		if (zeroForOne && sqrtPriceNextX96.Cmp(sqrtPriceLimitX96) < 0) ||
+			(!zeroForOne && sqrtPriceNextX96.Cmp(sqrtPriceLimitX96) > 0) {
+			target = sqrtPriceLimitX96
+		}
+		step, err := ComputeSwapStep(state.sqrtRatioX96, target, state.liquidity, remaining, p.Fee)
+		if err != nil {
+			return nil, err
+		}
+		state.sqrtRatioX96 = step.SqrtRatioNextX96
+
+		if exactInput {
+			remaining.Sub(remaining, new(big.Int).Add(step.AmountIn, step.FeeAmount))
+			state.amountCalculated.Sub(state.amountCalculated, step.AmountOut)
+		} else {
+			remaining.Add(remaining, step.AmountOut)
+			state.amountCalculated.Add(state.amountCalculated, new(big.Int).Add(step.AmountIn, step.FeeAmount))
+		}
+
+		switch {
+		case state.sqrtRatioX96.Cmp(sqrtPriceNextX96) == 0:
+			// if the tick is initialized, run the tick transition
+			if initialized {
+				tick, err := p.Ticks.Tick(tickNext)
+				if err != nil {
+					return nil, err
+				}
+				liquidityNet := tick.LiquidityNet
+				// if we're moving leftward, we interpret liquidityNet as the opposite sign
+				if zeroForOne {
+					liquidityNet = new(big.Int).Neg(liquidityNet)
+				}
+				state.liquidity, err = addDelta(state.liquidity, liquidityNet)
+				if err != nil {
+					return nil, err
+				}
+			}
+			state.tickCurrent = tickNext
+			if zeroForOne {
+				state.tickCurrent = tickNext - 1
+			}
+		case state.sqrtRatioX96.Cmp(sqrtPriceStartX96) != 0:
+			// recompute unless we're on a lower tick boundary (i.e. already transitioned ticks), and haven't moved
+			state.tickCurrent, err = GetTickAtSqrtRatio(state.sqrtRatioX96)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// without a price limit, the pool ran out of liquidity before swapping the whole amount
+	if remaining.Sign() != 0 && !limited {
+		return nil, ErrInsufficientLiquidity
+	}
+	return state, nil
+}
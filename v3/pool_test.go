@@ -0,0 +1,275 @@
+package v3
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/miraclesu/uniswap-sdk-go/constants"
+	"github.com/miraclesu/uniswap-sdk-go/entities"
+)
+
+var (
+	USDC, _ = entities.NewToken(constants.Mainnet, common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"), 6, "USDC", "USD Coin")
+	DAI, _  = entities.NewToken(constants.Mainnet, common.HexToAddress("0x6B175474E89094C44Da98b954EedeAC495271d0F"), 18, "DAI", "DAI Stablecoin")
+)
+
+func mustTokenAmount(t *testing.T, token *entities.Token, amount int64) *entities.TokenAmount {
+	tokenAmount, err := entities.NewTokenAmount(token, big.NewInt(amount))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tokenAmount
+}
+
+func TestNewPool(t *testing.T) {
+	oneEther := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+	// token0 is DAI, so the price is USDC over DAI
+	pool, err := NewPool(USDC, DAI, FeeLow, EncodeSqrtRatioX96(big.NewInt(101e6), new(big.Int).Mul(big.NewInt(100), oneEther)), big.NewInt(0), -276225, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pool.Token0().Equals(DAI) || !pool.Token1().Equals(USDC) {
+		t.Errorf("tokens are not sorted")
+	}
+	{
+		expect := "1.01"
+		if output := pool.Token0Price().ToSignificant(5); output != expect {
+			t.Errorf("expect[%+v], but got[%+v]", expect, output)
+		}
+	}
+	{
+		expect := "0.9901"
+		if output := pool.Token1Price().ToSignificant(5); output != expect {
+			t.Errorf("expect[%+v], but got[%+v]", expect, output)
+		}
+	}
+
+	tests := []struct {
+		Name   string
+		Fee    FeeAmount
+		Tick   int
+		Expect error
+	}{
+		{"fee", 1, 0, ErrInvalidFee},
+		{"price out of the current tick", FeeMedium, 1, ErrInvalidSqrtRatio},
+		{"tick", FeeMedium, MaxTick, ErrInvalidTick},
+	}
+	for _, test := range tests {
+		_, err := NewPool(USDC, DAI, test.Fee, Q96, big.NewInt(0), test.Tick, nil)
+		if err != test.Expect {
+			t.Errorf("%s: expect[%+v], but got[%+v]", test.Name, test.Expect, err)
+		}
+	}
+}
+
+func TestGetPoolAddress(t *testing.T) {
+	address, err := GetPoolAddress(FactoryAddress, entities.WETH[constants.Mainnet], USDC, FeeLow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := common.HexToAddress("0x88e6A0c2dDD26FEEb64F039a2c41296FcB3f5640")
+	if address != expect {
+		t.Errorf("expect[%+v], but got[%+v]", expect.Hex(), address.Hex())
+	}
+}
+
+// nolint funlen
+func TestPoolSwap(t *testing.T) {
+	oneEther := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+	// full range liquidity, nearest usable ticks of MinTick and MaxTick
+	pool, err := NewPool(USDC, DAI, FeeLow, EncodeSqrtRatioX96(big.NewInt(1), big.NewInt(1)), oneEther, 0, []*Tick{
+		{Index: -887270, LiquidityGross: oneEther, LiquidityNet: oneEther},
+		{Index: 887270, LiquidityGross: oneEther, LiquidityNet: new(big.Int).Neg(oneEther)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		Name   string
+		Exact  *entities.TokenAmount
+		Expect *entities.TokenAmount
+		Input  bool
+	}{
+		{"USDC -> DAI", mustTokenAmount(t, USDC, 100), mustTokenAmount(t, DAI, 98), true},
+		{"DAI -> USDC", mustTokenAmount(t, DAI, 100), mustTokenAmount(t, USDC, 98), true},
+		{"USDC -> DAI exact output", mustTokenAmount(t, DAI, 98), mustTokenAmount(t, USDC, 100), false},
+		{"DAI -> USDC exact output", mustTokenAmount(t, USDC, 98), mustTokenAmount(t, DAI, 100), false},
+	}
+	for _, test := range tests {
+		var output *entities.TokenAmount
+		var next *Pool
+		if test.Input {
+			output, next, err = pool.GetOutputAmount(test.Exact, nil)
+		} else {
+			output, next, err = pool.GetInputAmount(test.Exact, nil)
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", test.Name, err)
+		}
+		if !output.Token.Equals(test.Expect.Token) || output.Raw().Cmp(test.Expect.Raw()) != 0 {
+			t.Errorf("%s: expect[%+v], but got[%+v]", test.Name, test.Expect.Raw(), output.Raw())
+		}
+		if next.SqrtRatioX96.Cmp(pool.SqrtRatioX96) == 0 {
+			t.Errorf("%s: the price should move", test.Name)
+		}
+	}
+
+	if _, _, err := pool.GetOutputAmount(mustTokenAmount(t, entities.WETH[constants.Mainnet], 100), nil); err != entities.ErrDiffToken {
+		t.Errorf("expect[%+v], but got[%+v]", entities.ErrDiffToken, err)
+	}
+	if _, _, err := pool.GetOutputAmount(mustTokenAmount(t, USDC, 1), nil); err != entities.ErrInsufficientInputAmount {
+		t.Errorf("expect[%+v], but got[%+v]", entities.ErrInsufficientInputAmount, err)
+	}
+	if _, _, err := pool.GetOutputAmount(mustTokenAmount(t, USDC, 100), Q96); err != ErrInvalidPriceLimit {
+		t.Errorf("expect[%+v], but got[%+v]", ErrInvalidPriceLimit, err)
+	}
+}
+
+// nolint funlen
+func TestPoolCrossTicks(t *testing.T) {
+	liquidity := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+	ticks := []*Tick{
+		{Index: -600, LiquidityGross: liquidity, LiquidityNet: liquidity},
+		{Index: -60, LiquidityGross: liquidity, LiquidityNet: liquidity},
+		{Index: 60, LiquidityGross: liquidity, LiquidityNet: new(big.Int).Neg(liquidity)},
+		{Index: 600, LiquidityGross: liquidity, LiquidityNet: new(big.Int).Neg(liquidity)},
+	}
+	pool, err := NewPool(USDC, DAI, FeeMedium, Q96, new(big.Int).Mul(liquidity, big.NewInt(2)), 0, ticks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// selling enough DAI (token0) to cross the tick -60 halves the liquidity
+	sqrtRatioLower, _ := GetSqrtRatioAtTick(-60)
+	amountToLower := GetAmount0Delta(sqrtRatioLower, Q96, pool.Liquidity, true)
+	input := new(big.Int).Mul(amountToLower, big.NewInt(2))
+	inputAmount, err := entities.NewTokenAmount(DAI, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outputAmount, next, err := pool.GetOutputAmount(inputAmount, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next.Liquidity.Cmp(liquidity) != 0 {
+		t.Errorf("expect[%+v], but got[%+v]", liquidity, next.Liquidity)
+	}
+	if next.TickCurrent >= -60 || next.TickCurrent < -600 {
+		t.Errorf("unexpected tick[%+v]", next.TickCurrent)
+	}
+	// swapping in two steps through the next pool gives the same output, up to rounding
+	half, _ := entities.NewTokenAmount(DAI, new(big.Int).Quo(input, big.NewInt(2)))
+	first, middle, err := pool.GetOutputAmount(half, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _, err := middle.GetOutputAmount(half, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff := new(big.Int).Sub(outputAmount.Raw(), new(big.Int).Add(first.Raw(), second.Raw()))
+	if diff.Sign() < 0 || diff.Cmp(big.NewInt(2)) > 0 {
+		t.Errorf("expect[%+v], but got[%+v]", outputAmount.Raw(), new(big.Int).Add(first.Raw(), second.Raw()))
+	}
+
+	// the exact output quote of the output is the input, up to rounding
+	quote, back, err := pool.GetInputAmount(outputAmount, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if quote.Raw().Cmp(input) > 0 || new(big.Int).Sub(input, quote.Raw()).Cmp(big.NewInt(1000)) > 0 {
+		t.Errorf("expect[%+v], but got[%+v]", input, quote.Raw())
+	}
+	if back.TickCurrent != next.TickCurrent || back.Liquidity.Cmp(next.Liquidity) != 0 {
+		t.Errorf("expect[%+v], but got[%+v]", next.TickCurrent, back.TickCurrent)
+	}
+
+	// the swap stops at the price limit
+	limitAmount, limited, err := pool.GetOutputAmount(inputAmount, sqrtRatioLower)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if limited.SqrtRatioX96.Cmp(sqrtRatioLower) != 0 || limitAmount.Raw().Cmp(outputAmount.Raw()) >= 0 {
+		t.Errorf("unexpected limited swap[%+v]", limited.SqrtRatioX96)
+	}
+
+	// the liquidity runs out after the tick -600
+	all, _ := entities.NewTokenAmount(USDC, new(big.Int).Mul(liquidity, big.NewInt(1000)))
+	if _, _, err := pool.GetInputAmount(all, nil); err != ErrInsufficientLiquidity {
+		t.Errorf("expect[%+v], but got[%+v]", ErrInsufficientLiquidity, err)
+	}
+}
+
+// nolint funlen
+func TestPoolRoute(t *testing.T) {
+	oneEther := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+	pool, err := NewPool(USDC, DAI, FeeLow, EncodeSqrtRatioX96(big.NewInt(1), big.NewInt(1)), oneEther, 0, []*Tick{
+		{Index: -887270, LiquidityGross: oneEther, LiquidityNet: oneEther},
+		{Index: 887270, LiquidityGross: oneEther, LiquidityNet: new(big.Int).Neg(oneEther)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	weth := entities.WETH[constants.Mainnet]
+	pair, err := entities.NewPair(mustTokenAmount(t, DAI, 1000000), mustTokenAmount(t, weth, 1000000))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// routes through a pool and a pair
+	route, err := entities.NewPoolRoute([]entities.Pool{pool, pair}, USDC, weth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if route.Pairs != nil || len(route.Path) != 3 || !route.Path[1].Equals(DAI) {
+		t.Errorf("wrong route %+v", route)
+	}
+	{
+		expect := "1"
+		if output := route.MidPrice.Raw().ToSignificant(6); output != expect {
+			t.Errorf("expect[%+v], but got[%+v]", expect, output)
+		}
+	}
+
+	expect, _, err := pair.GetOutputAmount(mustTokenAmount(t, DAI, 98))
+	if err != nil {
+		t.Fatal(err)
+	}
+	trade, err := entities.ExactIn(route, mustTokenAmount(t, USDC, 100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if output := trade.OutputAmount(); output.Raw().Cmp(expect.Raw()) != 0 {
+		t.Errorf("expect[%+v], but got[%+v]", expect.Raw(), output.Raw())
+	}
+	if trade.NextMidPrice.EqualTo(route.MidPrice.Fraction) {
+		t.Errorf("the mid price should move")
+	}
+
+	// the best trade search goes through both kinds of pools, and skips the pool without enough liquidity
+	{
+		trades, err := entities.BestPoolTradeExactIn([]entities.Pool{pair, pool}, mustTokenAmount(t, USDC, 100), weth, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(trades) != 1 || trades[0].OutputAmount().Raw().Cmp(expect.Raw()) != 0 {
+			t.Errorf("wrong trades %+v", trades)
+		}
+	}
+	{
+		empty, err := NewPool(USDC, DAI, FeeLow, EncodeSqrtRatioX96(big.NewInt(1), big.NewInt(1)), big.NewInt(0), 0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		trades, err := entities.BestPoolTradeExactOut([]entities.Pool{empty, pair}, USDC, mustTokenAmount(t, weth, 10), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(trades) != 0 {
+			t.Errorf("wrong trades %+v", trades)
+		}
+	}
+}
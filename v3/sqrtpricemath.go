@@ -0,0 +1,133 @@
+package v3
+
+import (
+	"math/big"
+)
+
+// multiplyIn256 returns x * y truncated to uint256, as the overflowing multiplication of solidity
+func multiplyIn256(x, y *big.Int) *big.Int {
+	product := new(big.Int).Mul(x, y)
+	return product.And(product, maxUint256)
+}
+
+// addIn256 returns x + y truncated to uint256, as the overflowing addition of solidity
+func addIn256(x, y *big.Int) *big.Int {
+	sum := new(big.Int).Add(x, y)
+	return sum.And(sum, maxUint256)
+}
+
+// GetAmount0Delta returns the amount0 delta between two prices, i.e.
+// liquidity / sqrt(lower) - liquidity / sqrt(upper)
+// @param sqrtRatioAX96 a sqrt price
+// @param sqrtRatioBX96 another sqrt price
+// @param liquidity the amount of usable liquidity
+// @param roundUp whether to round the amount up or down
+func GetAmount0Delta(sqrtRatioAX96, sqrtRatioBX96, liquidity *big.Int, roundUp bool) *big.Int {
+	if sqrtRatioAX96.Cmp(sqrtRatioBX96) > 0 {
+		sqrtRatioAX96, sqrtRatioBX96 = sqrtRatioBX96, sqrtRatioAX96
+	}
+
+	numerator1 := new(big.Int).Lsh(liquidity, 96)
+	numerator2 := new(big.Int).Sub(sqrtRatioBX96, sqrtRatioAX96)
+	if roundUp {
+		return mulDivRoundingUp(mulDivRoundingUp(numerator1, numerator2, sqrtRatioBX96), big.NewInt(1), sqrtRatioAX96)
+	}
+	result := new(big.Int).Mul(numerator1, numerator2)
+	result.Quo(result, sqrtRatioBX96)
+	return result.Quo(result, sqrtRatioAX96)
+}
+
+// GetAmount1Delta returns the amount1 delta between two prices, i.e. liquidity * (sqrt(upper) - sqrt(lower))
+// @param sqrtRatioAX96 a sqrt price
+// @param sqrtRatioBX96 another sqrt price
+// @param liquidity the amount of usable liquidity
+// @param roundUp whether to round the amount up or down
+func GetAmount1Delta(sqrtRatioAX96, sqrtRatioBX96, liquidity *big.Int, roundUp bool) *big.Int {
+	if sqrtRatioAX96.Cmp(sqrtRatioBX96) > 0 {
+		sqrtRatioAX96, sqrtRatioBX96 = sqrtRatioBX96, sqrtRatioAX96
+	}
+
+	delta := new(big.Int).Sub(sqrtRatioBX96, sqrtRatioAX96)
+	if roundUp {
+		return mulDivRoundingUp(liquidity, delta, Q96)
+	}
+	result := new(big.Int).Mul(liquidity, delta)
+	return result.Quo(result, Q96)
+}
+
+// GetNextSqrtPriceFromInput returns the next sqrt price given an input amount of token0 or token1, rounding so that
+// the price does not pass the target price
+// @param sqrtPX96 the starting price, i.e. before accounting for the input amount
+// @param liquidity the amount of usable liquidity
+// @param amountIn how much of token0, or token1, is being swapped in
+// @param zeroForOne whether the amount in is token0 or token1
+func GetNextSqrtPriceFromInput(sqrtPX96, liquidity, amountIn *big.Int, zeroForOne bool) (*big.Int, error) {
+	if sqrtPX96.Sign() <= 0 || liquidity.Sign() <= 0 {
+		return nil, ErrInvalidLiquidity
+	}
+	if zeroForOne {
+		return getNextSqrtPriceFromAmount0RoundingUp(sqrtPX96, liquidity, amountIn, true)
+	}
+	return getNextSqrtPriceFromAmount1RoundingDown(sqrtPX96, liquidity, amountIn, true)
+}
+
+// GetNextSqrtPriceFromOutput returns the next sqrt price given an output amount of token0 or token1, rounding so
+// that the price passes the target price
+// @param sqrtPX96 the starting price, i.e. before accounting for the output amount
+// @param liquidity the amount of usable liquidity
+// @param amountOut how much of token0, or token1, is being swapped out
+// @param zeroForOne whether the amount out is token1 or token0
+func GetNextSqrtPriceFromOutput(sqrtPX96, liquidity, amountOut *big.Int, zeroForOne bool) (*big.Int, error) {
+	if sqrtPX96.Sign() <= 0 || liquidity.Sign() <= 0 {
+		return nil, ErrInvalidLiquidity
+	}
+	if zeroForOne {
+		return getNextSqrtPriceFromAmount1RoundingDown(sqrtPX96, liquidity, amountOut, false)
+	}
+	return getNextSqrtPriceFromAmount0RoundingUp(sqrtPX96, liquidity, amountOut, false)
+}
+
+// getNextSqrtPriceFromAmount0RoundingUp returns liquidity * sqrtPX96 / (liquidity +- amount * sqrtPX96) rounded up,
+// or liquidity / (liquidity / sqrtPX96 +- amount) if it overflows
+func getNextSqrtPriceFromAmount0RoundingUp(sqrtPX96, liquidity, amount *big.Int, add bool) (*big.Int, error) {
+	if amount.Sign() == 0 {
+		return sqrtPX96, nil
+	}
+
+	numerator1 := new(big.Int).Lsh(liquidity, 96)
+	product := multiplyIn256(amount, sqrtPX96)
+	if add {
+		if new(big.Int).Quo(product, amount).Cmp(sqrtPX96) == 0 {
+			denominator := addIn256(numerator1, product)
+			if denominator.Cmp(numerator1) >= 0 {
+				return mulDivRoundingUp(numerator1, sqrtPX96, denominator), nil
+			}
+		}
+		denominator := new(big.Int).Quo(numerator1, sqrtPX96)
+		return mulDivRoundingUp(numerator1, big.NewInt(1), denominator.Add(denominator, amount)), nil
+	}
+
+	if new(big.Int).Quo(product, amount).Cmp(sqrtPX96) != 0 || numerator1.Cmp(product) <= 0 {
+		return nil, ErrInsufficientLiquidity
+	}
+	return mulDivRoundingUp(numerator1, sqrtPX96, new(big.Int).Sub(numerator1, product)), nil
+}
+
+// getNextSqrtPriceFromAmount1RoundingDown returns sqrtPX96 +- amount / liquidity rounded down
+func getNextSqrtPriceFromAmount1RoundingDown(sqrtPX96, liquidity, amount *big.Int, add bool) (*big.Int, error) {
+	if add {
+		quotient := new(big.Int).Lsh(amount, 96)
+		quotient.Quo(quotient, liquidity)
+		result := quotient.Add(sqrtPX96, quotient)
+		if result.Cmp(maxUint160) > 0 {
+			return nil, ErrOverflow
+		}
+		return result, nil
+	}
+
+	quotient := mulDivRoundingUp(amount, Q96, liquidity)
+	if sqrtPX96.Cmp(quotient) <= 0 {
+		return nil, ErrInsufficientLiquidity
+	}
+	return quotient.Sub(sqrtPX96, quotient), nil
+}
@@ -0,0 +1,79 @@
+package v3
+
+import (
+	"math/big"
+	"testing"
+)
+
+func expandTo18Decimals(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil))
+}
+
+func encodePriceSqrt(reserve1, reserve0 int64) *big.Int {
+	return EncodeSqrtRatioX96(big.NewInt(reserve1), big.NewInt(reserve0))
+}
+
+func TestGetNextSqrtPrice(t *testing.T) {
+	price := encodePriceSqrt(1, 1)
+	liquidity := expandTo18Decimals(1)
+	amount := new(big.Int).Quo(expandTo18Decimals(1), big.NewInt(10))
+
+	tests := []struct {
+		Name       string
+		Input      bool
+		ZeroForOne bool
+		Amount     *big.Int
+		Expect     string
+	}{
+		{"input amount of 0.1 token1", true, false, amount, "87150978765690771352898345369"},
+		{"input amount of 0.1 token0", true, true, amount, "72025602285694852357767227579"},
+		{"zero input amount", true, true, big.NewInt(0), price.String()},
+		{"output amount of 0.1 token1", false, true, amount, "71305346262837903834189555302"},
+		{"output amount of 0.1 token0", false, false, amount, "88031291682515930659493278152"},
+	}
+	for _, test := range tests {
+		var output *big.Int
+		var err error
+		if test.Input {
+			output, err = GetNextSqrtPriceFromInput(price, liquidity, test.Amount, test.ZeroForOne)
+		} else {
+			output, err = GetNextSqrtPriceFromOutput(price, liquidity, test.Amount, test.ZeroForOne)
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", test.Name, err)
+		}
+		if output.String() != test.Expect {
+			t.Errorf("%s: expect[%+v], but got[%+v]", test.Name, test.Expect, output)
+		}
+	}
+
+	// the output can not exceed the virtual reserves
+	if _, err := GetNextSqrtPriceFromOutput(price, liquidity, expandTo18Decimals(1), false); err != ErrInsufficientLiquidity {
+		t.Errorf("expect[%+v], but got[%+v]", ErrInsufficientLiquidity, err)
+	}
+	if _, err := GetNextSqrtPriceFromInput(price, big.NewInt(0), amount, true); err != ErrInvalidLiquidity {
+		t.Errorf("expect[%+v], but got[%+v]", ErrInvalidLiquidity, err)
+	}
+}
+
+func TestGetAmountDelta(t *testing.T) {
+	lower, upper := encodePriceSqrt(1, 1), encodePriceSqrt(121, 100)
+	liquidity := expandTo18Decimals(1)
+
+	tests := []struct {
+		Name   string
+		Output *big.Int
+		Expect string
+	}{
+		{"amount0 rounded up", GetAmount0Delta(lower, upper, liquidity, true), "90909090909090910"},
+		{"amount0 rounded down", GetAmount0Delta(upper, lower, liquidity, false), "90909090909090909"},
+		{"amount1 rounded up", GetAmount1Delta(lower, upper, liquidity, true), "100000000000000000"},
+		{"amount1 rounded down", GetAmount1Delta(upper, lower, liquidity, false), "99999999999999999"},
+		{"zero liquidity", GetAmount0Delta(lower, upper, big.NewInt(0), true), "0"},
+	}
+	for _, test := range tests {
+		if test.Output.String() != test.Expect {
+			t.Errorf("%s: expect[%+v], but got[%+v]", test.Name, test.Expect, test.Output)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package v3
+
+import (
+	"math/big"
+)
+
+// SwapStep the result of swapping within a single tick range
+type SwapStep struct {
+	// the price after swapping the amount in or out, not to exceed the price target
+	SqrtRatioNextX96 *big.Int
+	// the amount to be swapped in, of either token0 or token1, based on the direction of the swap
+	AmountIn *big.Int
+	// the amount to be received, of either token0 or token1, based on the direction of the swap
+	AmountOut *big.Int
+	// the amount of input that will be taken as a fee
+	FeeAmount *big.Int
+}
+
+// ComputeSwapStep computes the result of swapping some amount in, or amount out, given the parameters of the swap.
+// The fee, plus the amount in, will never exceed the amount remaining if the swap's amountSpecified is positive.
+// @param sqrtRatioCurrentX96 the current sqrt price of the pool
+// @param sqrtRatioTargetX96 the price that cannot be exceeded, from which the direction of the swap is inferred
+// @param liquidity the usable liquidity
+// @param amountRemaining how much input or output amount is remaining to be swapped in/out, positive for exact input
+// and negative for exact output
+// @param feePips the fee taken from the input amount, expressed in hundredths of a bip
+func ComputeSwapStep(sqrtRatioCurrentX96, sqrtRatioTargetX96, liquidity, amountRemaining *big.Int, feePips FeeAmount) (*SwapStep, error) {
+	fee := big.NewInt(int64(feePips))
+	feeComplement := new(big.Int).Sub(feeDenominator, fee)
+	zeroForOne := sqrtRatioCurrentX96.Cmp(sqrtRatioTargetX96) >= 0
+	exactIn := amountRemaining.Sign() >= 0
+	step := &SwapStep{}
+
+	var err error
+	if exactIn {
+		amountRemainingLessFee := new(big.Int).Mul(amountRemaining, feeComplement)
+		amountRemainingLessFee.Quo(amountRemainingLessFee, feeDenominator)
+		if zeroForOne {
+			step.AmountIn = GetAmount0Delta(sqrtRatioTargetX96, sqrtRatioCurrentX96, liquidity, true)
+		} else {
+			step.AmountIn = GetAmount1Delta(sqrtRatioCurrentX96, sqrtRatioTargetX96, liquidity, true)
+		}
+		if amountRemainingLessFee.Cmp(step.AmountIn) >= 0 {
+			step.SqrtRatioNextX96 = sqrtRatioTargetX96
+		} else {
+			step.SqrtRatioNextX96, err = GetNextSqrtPriceFromInput(sqrtRatioCurrentX96, liquidity, amountRemainingLessFee, zeroForOne)
+		}
+	} else {
+		if zeroForOne {
+			step.AmountOut = GetAmount1Delta(sqrtRatioTargetX96, sqrtRatioCurrentX96, liquidity, false)
+		} else {
+			step.AmountOut = GetAmount0Delta(sqrtRatioCurrentX96, sqrtRatioTargetX96, liquidity, false)
+		}
+		if new(big.Int).Neg(amountRemaining).Cmp(step.AmountOut) >= 0 {
+			step.SqrtRatioNextX96 = sqrtRatioTargetX96
+		} else {
+			step.SqrtRatioNextX96, err = GetNextSqrtPriceFromOutput(sqrtRatioCurrentX96, liquidity, new(big.Int).Neg(amountRemaining), zeroForOne)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	max := sqrtRatioTargetX96.Cmp(step.SqrtRatioNextX96) == 0
+	// get the input/output amounts
+	if zeroForOne {
+		if !(max && exactIn) {
+			step.AmountIn = GetAmount0Delta(step.SqrtRatioNextX96, sqrtRatioCurrentX96, liquidity, true)
+		}
+		if !(max && !exactIn) {
+			step.AmountOut = GetAmount1Delta(step.SqrtRatioNextX96, sqrtRatioCurrentX96, liquidity, false)
+		}
+	} else {
+		if !(max && exactIn) {
+			step.AmountIn = GetAmount1Delta(sqrtRatioCurrentX96, step.SqrtRatioNextX96, liquidity, true)
+		}
+		if !(max && !exactIn) {
+			step.AmountOut = GetAmount0Delta(sqrtRatioCurrentX96, step.SqrtRatioNextX96, liquidity, false)
+		}
+	}
+
+	// cap the output amount to not exceed the remaining output amount
+	if !exactIn && step.AmountOut.Cmp(new(big.Int).Neg(amountRemaining)) > 0 {
+		step.AmountOut = new(big.Int).Neg(amountRemaining)
+	}
+
+	if exactIn && step.SqrtRatioNextX96.Cmp(sqrtRatioTargetX96) != 0 {
+		// we didn't reach the target, so take the remainder of the maximum input as fee
+		step.FeeAmount = new(big.Int).Sub(amountRemaining, step.AmountIn)
+	} else {
+		step.FeeAmount = mulDivRoundingUp(step.AmountIn, fee, feeComplement)
+	}
+	return step, nil
+}
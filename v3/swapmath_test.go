@@ -0,0 +1,105 @@
+package v3
+
+import (
+	"math/big"
+	"testing"
+)
+
+// nolint funlen
+func TestComputeSwapStep(t *testing.T) {
+	price := encodePriceSqrt(1, 1)
+	liquidity := expandTo18Decimals(2)
+	amount := expandTo18Decimals(1)
+
+	// exact amount in that gets capped at price target in one for zero
+	{
+		target := encodePriceSqrt(101, 100)
+		step, err := ComputeSwapStep(price, target, liquidity, amount, 600)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect := &SwapStep{
+			SqrtRatioNextX96: target,
+			AmountIn:         big.NewInt(9975124224178055),
+			AmountOut:        big.NewInt(9925619580021728),
+			FeeAmount:        big.NewInt(5988667735148),
+		}
+		assertStep(t, expect, step)
+	}
+
+	// exact amount out that gets capped at price target in one for zero
+	{
+		target := encodePriceSqrt(101, 100)
+		step, err := ComputeSwapStep(price, target, liquidity, new(big.Int).Neg(amount), 600)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect := &SwapStep{
+			SqrtRatioNextX96: target,
+			AmountIn:         big.NewInt(9975124224178055),
+			AmountOut:        big.NewInt(9925619580021728),
+			FeeAmount:        big.NewInt(5988667735148),
+		}
+		assertStep(t, expect, step)
+	}
+
+	// exact amount in that is fully spent in one for zero
+	{
+		target := encodePriceSqrt(1000, 100)
+		step, err := ComputeSwapStep(price, target, liquidity, amount, 600)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if step.SqrtRatioNextX96.Cmp(target) >= 0 {
+			t.Errorf("price should not reach the target")
+		}
+		// the whole amount is spent, as input or fee
+		if total := new(big.Int).Add(step.AmountIn, step.FeeAmount); total.Cmp(amount) != 0 {
+			t.Errorf("expect[%+v], but got[%+v]", amount, total)
+		}
+		{
+			expect := "999400000000000000"
+			if step.AmountIn.String() != expect {
+				t.Errorf("expect[%+v], but got[%+v]", expect, step.AmountIn)
+			}
+		}
+		{
+			expect := "666399946655997866"
+			if step.AmountOut.String() != expect {
+				t.Errorf("expect[%+v], but got[%+v]", expect, step.AmountOut)
+			}
+		}
+	}
+
+	// exact amount out that is fully received in one for zero
+	{
+		target := encodePriceSqrt(10000, 100)
+		step, err := ComputeSwapStep(price, target, liquidity, new(big.Int).Neg(amount), 600)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if step.AmountOut.Cmp(amount) != 0 {
+			t.Errorf("expect[%+v], but got[%+v]", amount, step.AmountOut)
+		}
+		{
+			expect := "2000000000000000000"
+			if step.AmountIn.String() != expect {
+				t.Errorf("expect[%+v], but got[%+v]", expect, step.AmountIn)
+			}
+		}
+		{
+			expect := "1200720432259356"
+			if step.FeeAmount.String() != expect {
+				t.Errorf("expect[%+v], but got[%+v]", expect, step.FeeAmount)
+			}
+		}
+	}
+}
+
+func assertStep(t *testing.T, expect, output *SwapStep) {
+	t.Helper()
+	if expect.SqrtRatioNextX96.Cmp(output.SqrtRatioNextX96) != 0 || expect.AmountIn.Cmp(output.AmountIn) != 0 ||
+		expect.AmountOut.Cmp(output.AmountOut) != 0 || expect.FeeAmount.Cmp(output.FeeAmount) != 0 {
+		t.Errorf("expect[%+v], but got[%+v]", expect, output)
+	}
+}
@@ -0,0 +1,98 @@
+package v3
+
+import (
+	"math/big"
+	"sort"
+)
+
+// Tick an initialized tick of a pool
+type Tick struct {
+	Index int
+	// the total liquidity referencing the tick
+	LiquidityGross *big.Int
+	// the liquidity added when the tick is crossed from left to right, i.e. as the price goes up
+	LiquidityNet *big.Int
+}
+
+// TickList the initialized ticks of a pool, sorted by index
+type TickList struct {
+	ticks       []*Tick
+	tickSpacing int
+}
+
+// NewTickList creates a TickList, the ticks must be sorted by index, be multiples of the tick spacing and their net
+// liquidity must sum to 0
+func NewTickList(ticks []*Tick, tickSpacing int) (*TickList, error) {
+	if tickSpacing <= 0 {
+		return nil, ErrInvalidTicks
+	}
+	sum := new(big.Int)
+	for i, tick := range ticks {
+		if tick.Index < MinTick || tick.Index > MaxTick || tick.Index%tickSpacing != 0 {
+			return nil, ErrInvalidTick
+		}
+		if (i > 0 && ticks[i-1].Index >= tick.Index) || tick.LiquidityGross.Sign() < 0 {
+			return nil, ErrInvalidTicks
+		}
+		sum.Add(sum, tick.LiquidityNet)
+	}
+	if sum.Sign() != 0 {
+		return nil, ErrInvalidTicks
+	}
+	return &TickList{ticks: ticks, tickSpacing: tickSpacing}, nil
+}
+
+// Ticks returns the ticks of the list
+func (l *TickList) Ticks() []*Tick {
+	return l.ticks
+}
+
+// Tick returns the initialized tick at the index
+func (l *TickList) Tick(index int) (*Tick, error) {
+	i := sort.Search(len(l.ticks), func(i int) bool {
+		return l.ticks[i].Index >= index
+	})
+	if i == len(l.ticks) || l.ticks[i].Index != index {
+		return nil, ErrInvalidTick
+	}
+	return l.ticks[i], nil
+}
+
+// floorDiv returns floor(a / b) for b > 0
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && a < 0 {
+		q--
+	}
+	return q
+}
+
+// nextInitializedTickWithinOneWord returns the next initialized tick to the left (less than or equal to) or to the
+// right (greater than) of the tick, within the word of 256 compressed ticks of the tick bitmap of the pool, and
+// whether it is initialized; the word boundary is returned if there is no initialized tick within the word
+func (l *TickList) nextInitializedTickWithinOneWord(tick int, lte bool) (int, bool) {
+	compressed := floorDiv(tick, l.tickSpacing)
+	if lte {
+		wordPos := compressed >> 8
+		minimum := (wordPos << 8) * l.tickSpacing
+		// the greatest initialized tick less than or equal to the tick
+		i := sort.Search(len(l.ticks), func(i int) bool {
+			return l.ticks[i].Index > tick
+		}) - 1
+		if i < 0 || l.ticks[i].Index < minimum {
+			return minimum, false
+		}
+		return l.ticks[i].Index, true
+	}
+
+	wordPos := (compressed + 1) >> 8
+	maximum := ((wordPos+1)<<8 - 1) * l.tickSpacing
+	// the least initialized tick greater than the tick
+	i := sort.Search(len(l.ticks), func(i int) bool {
+		return l.ticks[i].Index > tick
+	})
+	if i == len(l.ticks) || l.ticks[i].Index > maximum {
+		return maximum, false
+	}
+	return l.ticks[i].Index, true
+}
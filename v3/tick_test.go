@@ -0,0 +1,95 @@
+package v3
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestNewTickList(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Ticks  []*Tick
+		Expect error
+	}{
+		{"valid", []*Tick{
+			{Index: -60, LiquidityGross: big.NewInt(10), LiquidityNet: big.NewInt(10)},
+			{Index: 60, LiquidityGross: big.NewInt(10), LiquidityNet: big.NewInt(-10)},
+		}, nil},
+		{"unsorted", []*Tick{
+			{Index: 60, LiquidityGross: big.NewInt(10), LiquidityNet: big.NewInt(-10)},
+			{Index: -60, LiquidityGross: big.NewInt(10), LiquidityNet: big.NewInt(10)},
+		}, ErrInvalidTicks},
+		{"net liquidity", []*Tick{
+			{Index: -60, LiquidityGross: big.NewInt(10), LiquidityNet: big.NewInt(10)},
+			{Index: 60, LiquidityGross: big.NewInt(10), LiquidityNet: big.NewInt(-9)},
+		}, ErrInvalidTicks},
+		{"tick spacing", []*Tick{
+			{Index: -61, LiquidityGross: big.NewInt(10), LiquidityNet: big.NewInt(10)},
+			{Index: 60, LiquidityGross: big.NewInt(10), LiquidityNet: big.NewInt(-10)},
+		}, ErrInvalidTick},
+	}
+	for _, test := range tests {
+		if _, output := NewTickList(test.Ticks, 60); output != test.Expect {
+			t.Errorf("%s: expect[%+v], but got[%+v]", test.Name, test.Expect, output)
+		}
+	}
+}
+
+func TestNextInitializedTickWithinOneWord(t *testing.T) {
+	var ticks []*Tick
+	for _, index := range []int{-200, -55, -4, 70, 78, 84, 139, 240, 535} {
+		ticks = append(ticks, &Tick{Index: index, LiquidityGross: big.NewInt(1), LiquidityNet: big.NewInt(0)})
+	}
+	list, err := NewTickList(ticks, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		Tick        int
+		Lte         bool
+		Next        int
+		Initialized bool
+	}{
+		// words are 256 ticks wide for a tick spacing of 1
+		{78, false, 84, true},
+		{-55, false, -4, true},
+		{77, false, 78, true},
+		{-56, false, -55, true},
+		{255, false, 511, false},
+		{-257, false, -200, true},
+		{340, false, 511, false},
+		{508, false, 511, false},
+		{78, true, 78, true},
+		{79, true, 78, true},
+		{258, true, 256, false},
+		{256, true, 256, false},
+		{72, true, 70, true},
+		{-257, true, -512, false},
+		{1023, true, 768, false},
+	}
+	for _, test := range tests {
+		next, initialized := list.nextInitializedTickWithinOneWord(test.Tick, test.Lte)
+		if next != test.Next || initialized != test.Initialized {
+			t.Errorf("%d %v: expect[%d %v], but got[%d %v]", test.Tick, test.Lte, test.Next, test.Initialized, next, initialized)
+		}
+	}
+
+	// compressed ticks with a tick spacing
+	list, err = NewTickList([]*Tick{
+		{Index: -120, LiquidityGross: big.NewInt(1), LiquidityNet: big.NewInt(1)},
+		{Index: 60, LiquidityGross: big.NewInt(1), LiquidityNet: big.NewInt(-1)},
+	}, 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next, initialized := list.nextInitializedTickWithinOneWord(-121, true); next != -256*60 || initialized {
+		t.Errorf("expect[%d %v], but got[%d %v]", -256*60, false, next, initialized)
+	}
+	if next, initialized := list.nextInitializedTickWithinOneWord(60, false); next != 255*60 || initialized {
+		t.Errorf("expect[%d %v], but got[%d %v]", 255*60, false, next, initialized)
+	}
+	if next, initialized := list.nextInitializedTickWithinOneWord(-1, true); next != -120 || !initialized {
+		t.Errorf("expect[%d %v], but got[%d %v]", -120, true, next, initialized)
+	}
+}
@@ -0,0 +1,121 @@
+package v3
+
+import (
+	"math/big"
+)
+
+var (
+	// the sqrt ratios of the bits of the absolute tick, as Q128.128 numbers
+	tickRatios = []*big.Int{
+		hexToBig("fff97272373d413259a46990580e213a"),
+		hexToBig("fff2e50f5f656932ef12357cf3c7fdcc"),
+		hexToBig("ffe5caca7e10e4e61c3624eaa0941cd0"),
+		hexToBig("ffcb9843d60f6159c9db58835c926644"),
+		hexToBig("ff973b41fa98c081472e6896dfb254c0"),
+		hexToBig("ff2ea16466c96a3843ec78b326b52861"),
+		hexToBig("fe5dee046a99a2a811c461f1969c3053"),
+		hexToBig("fcbe86c7900a88aedcffc83b479aa3a4"),
+		hexToBig("f987a7253ac413176f2b074cf7815e54"),
+		hexToBig("f3392b0822b70005940c7a398e4b70f3"),
+		hexToBig("e7159475a2c29b7443b29c7fa6e889d9"),
+		hexToBig("d097f3bdfd2022b8845ad8f792aa5825"),
+		hexToBig("a9f746462d870fdf8a65dc1f90e061e5"),
+		hexToBig("70d869a156d2a1b890bb3df62baf32f7"),
+		hexToBig("31be135f97d08fd981231505542fcfa6"),
+		hexToBig("9aa508b5b7a84e1c677de54f3e99bc9"),
+		hexToBig("5d6af8dedb81196699c329225ee604"),
+		hexToBig("2216e584f5fa1ea926041bedfe98"),
+		hexToBig("48a170391f7dc42444e8fa2"),
+	}
+	tickRatio1 = hexToBig("fffcb933bd6fad37aa2d162d1a594001")
+	q128       = new(big.Int).Lsh(big.NewInt(1), 128)
+	q32        = new(big.Int).Lsh(big.NewInt(1), 32)
+
+	// converts log base 2 to log base sqrt(1.0001) as a Q128.128, and the error bounds of the tick
+	logSqrt10001, _  = new(big.Int).SetString("255738958999603826347141", 10)
+	tickLowError, _  = new(big.Int).SetString("3402992956809132418596140100660247210", 10)
+	tickHighError, _ = new(big.Int).SetString("291339464771989622907027621153398088495", 10)
+)
+
+func hexToBig(s string) *big.Int {
+	result, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("invalid hex " + s)
+	}
+	return result
+}
+
+// GetSqrtRatioAtTick returns the sqrt ratio as a Q64.96 for the given tick, the sqrt ratio is computed as
+// sqrt(1.0001)^tick
+// @param tick the tick for which to compute the sqrt ratio
+func GetSqrtRatioAtTick(tick int) (*big.Int, error) {
+	if tick < MinTick || tick > MaxTick {
+		return nil, ErrInvalidTick
+	}
+	absTick := tick
+	if tick < 0 {
+		absTick = -tick
+	}
+
+	ratio := new(big.Int).Set(q128)
+	if absTick&0x1 != 0 {
+		ratio.Set(tickRatio1)
+	}
+	for i, tickRatio := range tickRatios {
+		if absTick&(0x2<<uint(i)) != 0 {
+			ratio.Mul(ratio, tickRatio)
+			ratio.Rsh(ratio, 128)
+		}
+	}
+	if tick > 0 {
+		ratio.Quo(maxUint256, ratio)
+	}
+
+	// back to Q96, rounding up so that GetTickAtSqrtRatio of the result is the tick
+	result, remainder := new(big.Int).QuoRem(ratio, q32, new(big.Int))
+	if remainder.Sign() > 0 {
+		result.Add(result, big.NewInt(1))
+	}
+	return result, nil
+}
+
+// GetTickAtSqrtRatio returns the greatest tick whose sqrt ratio is less than or equal to the given sqrt ratio
+// @param sqrtRatioX96 the sqrt ratio as a Q64.96 for which to compute the tick
+func GetTickAtSqrtRatio(sqrtRatioX96 *big.Int) (int, error) {
+	if sqrtRatioX96.Cmp(MinSqrtRatio) < 0 || sqrtRatioX96.Cmp(MaxSqrtRatio) >= 0 {
+		return 0, ErrInvalidSqrtRatio
+	}
+
+	sqrtRatioX128 := new(big.Int).Lsh(sqrtRatioX96, 32)
+	msb := sqrtRatioX128.BitLen() - 1
+	r := new(big.Int)
+	if msb >= 128 {
+		r.Rsh(sqrtRatioX128, uint(msb-127))
+	} else {
+		r.Lsh(sqrtRatioX128, uint(127-msb))
+	}
+
+	log2 := new(big.Int).Lsh(big.NewInt(int64(msb-128)), 64)
+	for i := 0; i < 14; i++ {
+		r.Mul(r, r)
+		r.Rsh(r, 127)
+		f := new(big.Int).Rsh(r, 128)
+		log2.Or(log2, new(big.Int).Lsh(f, uint(63-i)))
+		r.Rsh(r, uint(f.Uint64()))
+	}
+
+	logSqrt := new(big.Int).Mul(log2, logSqrt10001)
+	tickLow := int(new(big.Int).Rsh(new(big.Int).Sub(logSqrt, tickLowError), 128).Int64())
+	tickHigh := int(new(big.Int).Rsh(new(big.Int).Add(logSqrt, tickHighError), 128).Int64())
+	if tickLow == tickHigh {
+		return tickLow, nil
+	}
+	sqrtRatioHigh, err := GetSqrtRatioAtTick(tickHigh)
+	if err != nil {
+		return 0, err
+	}
+	if sqrtRatioHigh.Cmp(sqrtRatioX96) <= 0 {
+		return tickHigh, nil
+	}
+	return tickLow, nil
+}
@@ -0,0 +1,78 @@
+package v3
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestGetSqrtRatioAtTick(t *testing.T) {
+	tests := []struct {
+		Tick   int
+		Expect string
+	}{
+		{MinTick, "4295128739"},
+		{MinTick + 1, "4295343490"},
+		{0, "79228162514264337593543950336"},
+		{MaxTick - 1, "1461373636630004318706518188784493106690254656249"},
+		{MaxTick, "1461446703485210103287273052203988822378723970342"},
+	}
+	for _, test := range tests {
+		output, err := GetSqrtRatioAtTick(test.Tick)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if output.String() != test.Expect {
+			t.Errorf("%d: expect[%+v], but got[%+v]", test.Tick, test.Expect, output)
+		}
+	}
+
+	for _, tick := range []int{MinTick - 1, MaxTick + 1} {
+		if _, err := GetSqrtRatioAtTick(tick); err != ErrInvalidTick {
+			t.Errorf("%d: expect[%+v], but got[%+v]", tick, ErrInvalidTick, err)
+		}
+	}
+}
+
+func TestGetTickAtSqrtRatio(t *testing.T) {
+	tests := []struct {
+		SqrtRatio *big.Int
+		Expect    int
+	}{
+		{MinSqrtRatio, MinTick},
+		{big.NewInt(4295343490), MinTick + 1},
+		{Q96, 0},
+		{new(big.Int).Sub(MaxSqrtRatio, big.NewInt(1)), MaxTick - 1},
+	}
+	for _, test := range tests {
+		output, err := GetTickAtSqrtRatio(test.SqrtRatio)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if output != test.Expect {
+			t.Errorf("%v: expect[%+v], but got[%+v]", test.SqrtRatio, test.Expect, output)
+		}
+	}
+
+	for _, sqrtRatio := range []*big.Int{new(big.Int).Sub(MinSqrtRatio, big.NewInt(1)), MaxSqrtRatio} {
+		if _, err := GetTickAtSqrtRatio(sqrtRatio); err != ErrInvalidSqrtRatio {
+			t.Errorf("%v: expect[%+v], but got[%+v]", sqrtRatio, ErrInvalidSqrtRatio, err)
+		}
+	}
+
+	// the tick of the sqrt ratio of a tick is the tick, and the tick of the sqrt ratio just below is the tick below
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		tick := r.Intn(MaxTick-MinTick-1) + MinTick + 1
+		sqrtRatio, err := GetSqrtRatioAtTick(tick)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if output, _ := GetTickAtSqrtRatio(sqrtRatio); output != tick {
+			t.Fatalf("expect[%+v], but got[%+v]", tick, output)
+		}
+		if output, _ := GetTickAtSqrtRatio(sqrtRatio.Sub(sqrtRatio, big.NewInt(1))); output != tick-1 {
+			t.Fatalf("expect[%+v], but got[%+v]", tick-1, output)
+		}
+	}
+}